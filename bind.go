@@ -0,0 +1,275 @@
+package router
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBindMemory is the memory used to parse the multipart forms, the files
+// beyond it are stored on disk
+const maxBindMemory = 32 << 20
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// bindSources are the struct tags of the request inputs, in the order they
+// are bound: the latest ones take priority
+var bindSources = []string{"form", "query", "header", "path"}
+
+// Bind fills the struct pointed by v from the request.
+// The body is decoded according to its content type: JSON and XML bodies
+// into the whole struct, forms into the fields with a `form:"name"` tag.
+// Then the fields with a `query:"name"`, `header:"Name"` or `path:"name"`
+// tag are set from the query params, the headers and the path params, with
+// the value of their `default:"value"` tag when the input is missing.
+// The values are converted to the type of the fields: strings, booleans,
+// numbers, time.Duration, time.Time in RFC 3339, encoding.TextUnmarshaler,
+// and slices and pointers of them. Anonymous struct fields are bound too.
+//
+// The inputs which can't be bound are returned as ValidationErrors, which
+// the router replies with 400 Bad Request when returned by a handler
+// registered with HandleE.
+func Bind(r *http.Request, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		panic("router: Bind requires a pointer to a struct")
+	}
+
+	var errs ValidationErrors
+
+	form, err := bindBody(r, v)
+	if err != nil {
+		var fieldErr FieldError
+		if !errors.As(err, &fieldErr) {
+			return err
+		}
+
+		errs = append(errs, fieldErr)
+	}
+
+	inputs := map[string]func(name string) ([]string, bool){
+		"form": func(name string) ([]string, bool) {
+			values, ok := form[name]
+			return values, ok
+		},
+		"query": func(name string) ([]string, bool) {
+			values, ok := r.URL.Query()[name]
+			return values, ok
+		},
+		"header": func(name string) ([]string, bool) {
+			values := r.Header.Values(name)
+			return values, len(values) > 0
+		},
+		"path": func(name string) ([]string, bool) {
+			value, ok := UserValues(r)[name]
+			return []string{value}, ok
+		},
+	}
+
+	for _, in := range bindSources {
+		if in == "form" && form == nil {
+			continue
+		}
+
+		errs = append(errs, bindFields(rv.Elem(), in, inputs[in])...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// bindBody decodes the body of the request into v, and returns the form
+// values of form bodies
+func bindBody(r *http.Request, v interface{}) (map[string][]string, error) {
+	contentType := r.Header.Get("Content-Type")
+	if r.Body == nil || r.Body == http.NoBody || contentType == "" {
+		return nil, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, FieldError{In: "header", Name: "Content-Type", Message: err.Error()}
+	}
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, FieldError{In: "body", Message: err.Error()}
+		}
+
+		return r.PostForm, nil
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(maxBindMemory); err != nil {
+			return nil, FieldError{In: "body", Message: err.Error()}
+		}
+
+		return r.MultipartForm.Value, nil
+	case isJSONMediaType(mediaType):
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+			return nil, FieldError{In: "body", Message: "invalid JSON: " + err.Error()}
+		}
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		if err := xml.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+			return nil, FieldError{In: "body", Message: "invalid XML: " + err.Error()}
+		}
+	default:
+		return nil, NewHTTPError(http.StatusUnsupportedMediaType, "unsupported media type '"+mediaType+"'")
+	}
+
+	return nil, nil
+}
+
+// bindFields sets the fields of the struct tagged with the given input
+func bindFields(rv reflect.Value, in string, lookup func(name string) ([]string, bool)) ValidationErrors {
+	var errs ValidationErrors
+
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		name, ok := field.Tag.Lookup(in)
+		if !ok {
+			if field.Anonymous && fv.Kind() == reflect.Struct {
+				errs = append(errs, bindFields(fv, in, lookup)...)
+			}
+
+			continue
+		}
+
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		values, ok := lookup(name)
+		if !ok || len(values) == 0 {
+			def, ok := field.Tag.Lookup("default")
+			if !ok {
+				continue
+			}
+
+			values = []string{def}
+		}
+
+		if err := setField(fv, values); err != nil {
+			errs = append(errs, FieldError{In: in, Name: name, Message: err.Error()})
+		}
+	}
+
+	return errs
+}
+
+// setField converts the values to the type of the field
+func setField(fv reflect.Value, values []string) error {
+	if fv.Kind() == reflect.Slice && !fv.Type().Implements(textUnmarshalerType) && fv.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+
+		for i, value := range values {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(slice)
+
+		return nil
+	}
+
+	return setValue(fv, values[0])
+}
+
+// setValue converts the value to the type of v
+func setValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+
+		v.Set(ptr)
+
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch v.Type() {
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("invalid duration '" + value + "'")
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	case timeType:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.New("invalid time '" + value + "'")
+		}
+
+		v.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("invalid boolean '" + value + "'")
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("invalid integer '" + value + "'")
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("invalid unsigned integer '" + value + "'")
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return errors.New("invalid number '" + value + "'")
+		}
+
+		v.SetFloat(f)
+	default:
+		return errors.New("unsupported type " + v.Type().String())
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type bindPage struct {
+	Page  int      `query:"page" default:"1"`
+	Sort  []string `query:"sort"`
+	Debug *bool    `query:"debug"`
+}
+
+type bindUser struct {
+	bindPage
+
+	ID      uint64        `path:"id" json:"-" xml:"-"`
+	Token   string        `header:"X-Token" json:"-" xml:"-"`
+	Timeout time.Duration `query:"timeout" default:"5s" json:"-" xml:"-"`
+	Name    string        `json:"name" xml:"name" form:"name"`
+	Age     int           `json:"age" xml:"age" form:"age"`
+	Since   time.Time     `form:"since" json:"since" xml:"since"`
+}
+
+func TestBind(t *testing.T) {
+	debug := true
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		contentType string
+		body        string
+		want        bindUser
+	}{
+		{"", "", bindUser{
+			bindPage: bindPage{Page: 2, Sort: []string{"name", "-age"}, Debug: &debug},
+			ID:       42, Token: "secret", Timeout: 5 * time.Second,
+		}},
+		{"application/json; charset=utf-8", `{"name":"gopher","age":12,"since":"2020-01-02T03:04:05Z"}`, bindUser{
+			bindPage: bindPage{Page: 2, Sort: []string{"name", "-age"}, Debug: &debug},
+			ID:       42, Token: "secret", Timeout: 5 * time.Second,
+			Name: "gopher", Age: 12, Since: since,
+		}},
+		{"application/xml", `<user><name>gopher</name><age>12</age><since>2020-01-02T03:04:05Z</since></user>`, bindUser{
+			bindPage: bindPage{Page: 2, Sort: []string{"name", "-age"}, Debug: &debug},
+			ID:       42, Token: "secret", Timeout: 5 * time.Second,
+			Name: "gopher", Age: 12, Since: since,
+		}},
+		{"application/x-www-form-urlencoded", `name=gopher&age=12&since=2020-01-02T03:04:05Z`, bindUser{
+			bindPage: bindPage{Page: 2, Sort: []string{"name", "-age"}, Debug: &debug},
+			ID:       42, Token: "secret", Timeout: 5 * time.Second,
+			Name: "gopher", Age: 12, Since: since,
+		}},
+	}
+
+	for _, test := range tests {
+		var got bindUser
+
+		r := New()
+		r.PUT("/users/{id}", func(w http.ResponseWriter, req *http.Request) {
+			if err := Bind(req, &got); err != nil {
+				t.Errorf("Content-Type %q: unexpected error: %v", test.contentType, err)
+			}
+		})
+
+		req := httptest.NewRequest(http.MethodPut, "/users/42?page=2&sort=name&sort=-age&debug=true", strings.NewReader(test.body))
+		req.Header.Set("X-Token", "secret")
+		if test.contentType != "" {
+			req.Header.Set("Content-Type", test.contentType)
+		}
+
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Content-Type %q: bound %+v, want %+v", test.contentType, got, test.want)
+		}
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	r := New()
+	r.PUTE("/users/{id}", func(w http.ResponseWriter, req *http.Request) error {
+		var user bindUser
+		return Bind(req, &user)
+	})
+
+	tests := []struct {
+		path        string
+		contentType string
+		body        string
+		code        int
+		response    string
+	}{
+		{
+			"/users/x?page=first&timeout=soon", "application/json", `{"age":"twelve"}`,
+			http.StatusBadRequest,
+			`{"errors":[` +
+				`{"in":"body","name":"","message":"invalid JSON: json: cannot unmarshal string into Go struct field bindUser.age of type int"},` +
+				`{"in":"query","name":"page","message":"invalid integer 'first'"},` +
+				`{"in":"query","name":"timeout","message":"invalid duration 'soon'"},` +
+				`{"in":"path","name":"id","message":"invalid unsigned integer 'x'"}]}` + "\n",
+		},
+		{"/users/1", "text/csv", "name,age", http.StatusUnsupportedMediaType, "unsupported media type 'text/csv'\n"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, test.path, strings.NewReader(test.body))
+		req.Header.Set("Content-Type", test.contentType)
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.response {
+			t.Errorf("PUT %s: code == %d, body == %q, want %d and %q", test.path, w.Code, w.Body.String(), test.code, test.response)
+		}
+	}
+}
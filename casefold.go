@@ -0,0 +1,75 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+type canonicalPathKey struct{}
+
+// CanonicalPath returns the registered path matched by the request, with
+// the case of the static segments of the route and the values of the params
+// of the request.
+// It only differs from the routing path of the request when it was matched
+// case-insensitively.
+func CanonicalPath(r *http.Request) string {
+	if path, ok := r.Context().Value(canonicalPathKey{}).(string); ok {
+		return path
+	}
+
+	return r.URL.Path
+}
+
+// CaseInsensitive overrides Router.CaseInsensitive for the routes of the
+// group and its sub-groups.
+// It must be set before registering the routes.
+func (g *Group) CaseInsensitive(v bool) *Group {
+	g.caseInsensitive = &v
+
+	if v {
+		g.router.caseGroups = true
+	}
+
+	return g
+}
+
+// caseInsensitive reports whether the static segments of the endpoint are
+// matched case-insensitively, according to the group of its first route
+// overriding the router mode
+func (ep *endpoint) caseInsensitive() bool {
+	for _, route := range ep.routes {
+		if route.caseInsensitive != nil {
+			return *route.caseInsensitive
+		}
+	}
+
+	return ep.router.CaseInsensitive
+}
+
+// serveCaseInsensitive serves the request with the route matching its path
+// case-insensitively, if any and if the route allows it
+func (router *Router) serveCaseInsensitive(w http.ResponseWriter, r *http.Request, methodIndex int, path string) bool {
+	if !router.CaseInsensitive && !router.caseGroups {
+		return false
+	}
+
+	buf := bytebufferpool.Get()
+	defer bytebufferpool.Put(buf)
+
+	if !router.matcher.FindCaseInsensitivePath(path, false, buf) {
+		return false
+	}
+
+	canonical := buf.String()
+
+	e, params := router.findEntry(methodIndex, canonical)
+	if e == nil || !e.endpoint.caseInsensitive() {
+		return false
+	}
+
+	e.serve(w, r.WithContext(context.WithValue(r.Context(), canonicalPathKey{}, canonical)), params)
+
+	return true
+}
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterCaseInsensitive(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(CanonicalPath(r) + " " + UserValue(r, "name")))
+	}
+
+	r := New()
+	r.CaseInsensitive = true
+	r.GET("/users/{name}/profile", handler)
+	r.POST("/Items", handler)
+	r.GET("/static/{filepath:*}", handler)
+
+	strict := r.Group("/admin").CaseInsensitive(false)
+	strict.GET("/users", handler)
+	strict.Group("/public").CaseInsensitive(true).GET("/docs", handler)
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/users/Gopher/profile", http.StatusOK, "/users/Gopher/profile Gopher"},
+		{http.MethodGet, "/USERS/Gopher/PROFILE", http.StatusOK, "/users/Gopher/profile Gopher"},
+		{http.MethodPost, "/items", http.StatusOK, "/Items "},
+		{http.MethodGet, "/Static/CSS/Main.css", http.StatusOK, "/static/CSS/Main.css "},
+		{http.MethodGet, "/admin/users", http.StatusOK, "/admin/users "},
+		{http.MethodGet, "/Admin/Users", http.StatusMovedPermanently, ""},
+		{http.MethodGet, "/ADMIN/PUBLIC/DOCS", http.StatusOK, "/admin/public/docs "},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		if w.Code != test.code {
+			t.Errorf("%s %s: status == %d, want %d", test.method, test.path, w.Code, test.code)
+		}
+
+		if test.code == http.StatusOK && w.Body.String() != test.body {
+			t.Errorf("%s %s: body == %q, want %q", test.method, test.path, w.Body.String(), test.body)
+		}
+	}
+
+	r.RedirectFixedPath = false
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/Admin/Users", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("case-sensitive group: status == %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestGroupCaseInsensitiveScope(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.RedirectFixedPath = false
+	r.Group("/api").CaseInsensitive(true).GET("/users", handler)
+	r.Group("/api").GET("/items", handler)
+	r.GET("/api/orders", handler)
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/API/USERS", http.StatusOK},
+		{"/API/ITEMS", http.StatusNotFound},
+		{"/API/ORDERS", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if w.Code != test.code {
+			t.Errorf("GET %s: status == %d, want %d", test.path, w.Code, test.code)
+		}
+	}
+}
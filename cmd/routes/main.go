@@ -0,0 +1,145 @@
+// Command routes prints the routes registered on the routers of Go
+// packages, by type-checking their source without running them.
+//
+// Usage:
+//
+//	routes [-format table|json|markdown] [packages]
+//
+// The calls to Router.Handle, the method shortcuts like GET and the other
+// registration methods are followed through the groups, including groups
+// assigned to variables. The prefixes of the groups which can't be followed,
+// e.g. received as function arguments, are printed as "…".
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pedia/router/internal/routescan"
+)
+
+// route is a route of the inventory
+type route struct {
+	*routescan.Route
+	Location string `json:"location"`
+}
+
+func main() {
+	format := flag.String("format", "table", "output format: table, json or markdown")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: routes [-format table|json|markdown] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	routes, err := inventory(patterns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "routes:", err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "table":
+		err = writeTable(os.Stdout, routes)
+	case "json":
+		err = writeJSON(os.Stdout, routes)
+	case "markdown", "md":
+		err = writeMarkdown(os.Stdout, routes)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "routes:", err)
+		os.Exit(1)
+	}
+}
+
+// inventory returns the routes registered in the packages
+func inventory(patterns []string) ([]route, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := routescan.Load(wd, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []route
+
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			fmt.Fprintln(os.Stderr, "routes: warning:", err)
+		}
+
+		for _, r := range routescan.Scan(pkg.Fset, pkg.Files, pkg.Info) {
+			file := r.Pos.Filename
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+
+			routes = append(routes, route{Route: r, Location: fmt.Sprintf("%s:%d", file, r.Pos.Line)})
+		}
+	}
+
+	return routes, nil
+}
+
+func writeTable(w io.Writer, routes []route) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tNAME\tHANDLER\tLOCATION")
+
+	for _, r := range routes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Method, r.Host+r.Path, r.Name, r.Handler, r.Location)
+	}
+
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, routes []route) error {
+	if routes == nil {
+		routes = []route{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(routes)
+}
+
+func writeMarkdown(w io.Writer, routes []route) error {
+	fmt.Fprintln(w, "| Method | Path | Name | Handler | Location |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+
+	for _, r := range routes {
+		_, err := fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+			r.Method, markdownCode(r.Host+r.Path), r.Name, markdownCode(r.Handler), r.Location)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return "`" + strings.ReplaceAll(s, "|", "\\|") + "`"
+}
@@ -0,0 +1,486 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CodegenOptions configures the code generated by GenerateCode
+type CodegenOptions struct {
+	// Package is the name of the generated package, "routes" by default
+	Package string
+
+	// Client enables the generation of a HTTP client with a method per
+	// named route
+	Client bool
+}
+
+// digitRegexes are the param regexes matching the unsigned integers, whose
+// params are generated as uint64
+var digitRegexes = map[string]bool{
+	"[0-9]+": true,
+	`\d+`:    true,
+}
+
+// codegenNames are the names of the generated code the params must not
+// shadow: the imported packages and the helpers
+var codegenNames = map[string]bool{
+	"context":    true,
+	"fmt":        true,
+	"http":       true,
+	"io":         true,
+	"regexp":     true,
+	"strconv":    true,
+	"strings":    true,
+	"url":        true,
+	"escapePath": true,
+}
+
+// codegenLocals are the local variables of the generated client methods
+var codegenLocals = map[string]bool{"ctx": true, "body": true, "c": true, "path": true, "err": true}
+
+// codegenParam is a param of a generated URL builder
+type codegenParam struct {
+	*patternParam
+	ident  string
+	goType string
+	regexp string // name of the variable of the compiled regex, if any
+}
+
+// codegenRoute is a named route with its generated identifiers
+type codegenRoute struct {
+	route   *Route
+	ident   string
+	params  []*codegenParam
+	paths   [][]patternToken // the paths of the optional variants, longest last
+	failing bool             // the builder returns an error
+}
+
+// GenerateCode returns the Go source of a function per named route
+// building its URL path, like URLUserShow(id uint64) string for a route
+// named "user.show", and optionally of a typed HTTP client.
+//
+// The params whose regex only matches digits, like {id:[0-9]+}, are
+// generated as uint64, the others as strings. The builders of routes with
+// other regex params check the values and return an error too. Optional
+// params are omitted when empty, the path is then truncated before them
+// like on registration. The params named like an imported package or like
+// another param of the route are renamed, e.g. urlParam or userID2.
+func (router *Router) GenerateCode(opts CodegenOptions) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "routes"
+	}
+
+	routes, err := router.codegenRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &codegen{imports: make(map[string]bool)}
+
+	for _, cr := range routes {
+		g.builder(cr)
+	}
+
+	if opts.Client {
+		g.client(routes)
+	}
+
+	var src bytes.Buffer
+
+	fmt.Fprintf(&src, "// Code generated by router.GenerateCode. DO NOT EDIT.\n\npackage %s\n\n", opts.Package)
+
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			imports = append(imports, strconv.Quote(path))
+		}
+
+		sort.Strings(imports)
+
+		fmt.Fprintf(&src, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+
+	src.Write(g.vars.Bytes())
+	src.Write(g.body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %v", err)
+	}
+
+	return formatted, nil
+}
+
+// codegenRoutes returns the named routes with their identifiers
+func (router *Router) codegenRoutes() ([]*codegenRoute, error) {
+	var routes []*codegenRoute
+
+	idents := make(map[string]string)
+	vars := make(map[string]bool)
+
+	for _, route := range router.routes {
+		if route.name == "" {
+			continue
+		}
+
+		cr := &codegenRoute{route: route, ident: exportedIdent(route.name)}
+		if cr.ident == "" {
+			return nil, fmt.Errorf("route '%s' has no valid Go name", route.name)
+		}
+
+		if other, ok := idents[cr.ident]; ok {
+			return nil, fmt.Errorf("routes '%s' and '%s' have the same Go name %s", other, route.name, cr.ident)
+		}
+
+		idents[cr.ident] = route.name
+
+		paths := getOptionalPaths(route.path)
+		if len(paths) == 0 {
+			paths = []string{route.path}
+		}
+
+		for _, path := range paths {
+			tokens, err := parsePattern(path)
+			if err != nil {
+				return nil, err
+			}
+
+			cr.paths = append(cr.paths, tokens)
+		}
+
+		params := make(map[string]bool)
+
+		for _, param := range patternParams(route.path) {
+			ident := paramIdent(param.name)
+			if codegenNames[ident] {
+				ident += "Param"
+			}
+
+			p := &codegenParam{patternParam: param, ident: uniqueIdent(ident, params), goType: "string"}
+			cr.params = append(cr.params, p)
+		}
+
+		for _, p := range cr.params {
+			switch {
+			case digitRegexes[p.regex] && !p.optional:
+				p.goType = "uint64"
+			case p.regex != "":
+				p.regexp = uniqueIdent("re"+cr.ident+exportedIdent(p.name), vars, params)
+				cr.failing = true
+			}
+		}
+
+		routes = append(routes, cr)
+	}
+
+	return routes, nil
+}
+
+// codegen writes the generated code
+type codegen struct {
+	imports map[string]bool
+	vars    bytes.Buffer
+	body    bytes.Buffer
+	helpers bool
+}
+
+func (g *codegen) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// builder writes the URL builder of the route
+func (g *codegen) builder(cr *codegenRoute) {
+	route := cr.route
+
+	args := make([]string, len(cr.params))
+	for i, p := range cr.params {
+		args[i] = p.ident + " " + p.goType
+	}
+
+	result := "string"
+	if cr.failing {
+		result = "(string, error)"
+	}
+
+	g.printf("// URL%s returns the path of the route %s: %s %s\n", cr.ident, route.name, route.method, route.path)
+	g.printf("func URL%s(%s) %s {\n", cr.ident, strings.Join(args, ", "), result)
+
+	for _, p := range cr.params {
+		if p.regexp == "" {
+			continue
+		}
+
+		g.imports["fmt"] = true
+		g.imports["regexp"] = true
+		fmt.Fprintf(&g.vars, "var %s = regexp.MustCompile(%s)\n\n", p.regexp, strconv.Quote(anchoredRegex(p.regex)))
+
+		check := fmt.Sprintf("!%s.MatchString(%s)", p.regexp, p.ident)
+		if p.optional {
+			check = p.ident + ` != "" && ` + check
+		}
+
+		g.printf("if %s {\n", check)
+		g.printf("return \"\", fmt.Errorf(\"invalid value %%q for param '%s' of route '%s'\", %s)\n}\n", p.name, route.name, p.ident)
+	}
+
+	ret := func(tokens []patternToken) string {
+		if cr.failing {
+			return "return " + g.pathExpr(tokens, cr.params) + ", nil\n"
+		}
+
+		return "return " + g.pathExpr(tokens, cr.params) + "\n"
+	}
+
+	// Optional params are omitted from the first empty one
+	last := cr.paths[len(cr.paths)-1]
+	variant := 0
+
+	for _, p := range cr.params {
+		if !p.optional {
+			continue
+		}
+
+		g.printf("if %s == \"\" {\n%s}\n", p.ident, ret(cr.paths[variant]))
+		variant++
+	}
+
+	g.printf("%s}\n\n", ret(last))
+}
+
+// pathExpr returns the expression of the path built from the tokens
+func (g *codegen) pathExpr(tokens []patternToken, params []*codegenParam) string {
+	parts := make([]string, 0, len(tokens))
+
+	for _, tok := range tokens {
+		if tok.param == nil {
+			parts = append(parts, strconv.Quote(tok.literal))
+			continue
+		}
+
+		var p *codegenParam
+		for _, param := range params {
+			if param.name == tok.param.name {
+				p = param
+			}
+		}
+
+		switch {
+		case p.goType == "uint64":
+			g.imports["strconv"] = true
+			parts = append(parts, "strconv.FormatUint("+p.ident+", 10)")
+		case p.catchAll:
+			g.escapeHelper()
+			parts = append(parts, "escapePath("+p.ident+")")
+		default:
+			g.imports["net/url"] = true
+			parts = append(parts, "url.PathEscape("+p.ident+")")
+		}
+	}
+
+	if len(parts) == 0 {
+		return `""`
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// escapeHelper writes the helper escaping the segments of catch-all values
+func (g *codegen) escapeHelper() {
+	if g.helpers {
+		return
+	}
+
+	g.helpers = true
+	g.imports["net/url"] = true
+	g.imports["strings"] = true
+
+	fmt.Fprint(&g.vars, `// escapePath escapes the segments of the path
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+`)
+}
+
+// client writes the HTTP client of the routes
+func (g *codegen) client(routes []*codegenRoute) {
+	g.imports["context"] = true
+	g.imports["io"] = true
+	g.imports["net/http"] = true
+	g.imports["strings"] = true
+
+	g.printf(`// Client calls the named routes of a server
+type Client struct {
+	// BaseURL is the URL of the server
+	BaseURL string
+
+	// HTTPClient sends the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client of the server at the base URL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+
+`)
+
+	for _, cr := range routes {
+		route := cr.route
+		if route.method == MethodWild {
+			continue
+		}
+
+		args := []string{"ctx context.Context"}
+		callArgs := make([]string, len(cr.params))
+		params := make(map[string]bool)
+
+		for i, p := range cr.params {
+			ident := p.ident
+			if codegenLocals[ident] {
+				ident += "Param"
+			}
+
+			ident = uniqueIdent(ident, params)
+			args = append(args, ident+" "+p.goType)
+			callArgs[i] = ident
+		}
+
+		body := "nil"
+		if hasBody(route.method) {
+			args = append(args, "body io.Reader")
+			body = "body"
+		}
+
+		contentType := ""
+		if len(route.consumes) > 0 && !strings.Contains(route.consumes[0], "*") {
+			contentType = route.consumes[0]
+		}
+
+		g.printf("// %s calls the route %s: %s %s\n", cr.ident, route.name, route.method, route.path)
+		g.printf("func (c *Client) %s(%s) (*http.Response, error) {\n", cr.ident, strings.Join(args, ", "))
+
+		if cr.failing {
+			g.printf("path, err := URL%s(%s)\nif err != nil {\nreturn nil, err\n}\n\n", cr.ident, strings.Join(callArgs, ", "))
+		} else {
+			g.printf("path := URL%s(%s)\n\n", cr.ident, strings.Join(callArgs, ", "))
+		}
+
+		g.printf("return c.do(ctx, %s, path, %s, %s)\n}\n\n", strconv.Quote(route.method), body, strconv.Quote(contentType))
+	}
+}
+
+// hasBody reports whether the requests of the method have a body
+func hasBody(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH":
+		return true
+	}
+
+	return false
+}
+
+// exportedIdent returns the exported Go identifier of the name, e.g.
+// UserShow for user.show
+func exportedIdent(name string) string {
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || (unicode.IsDigit(r) && b.Len() > 0):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+
+			b.WriteRune(r)
+			upper = false
+		case unicode.IsDigit(r):
+			// Identifiers can't start with a digit
+			b.WriteString("R")
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+
+	return b.String()
+}
+
+// paramIdent returns the Go identifier of the param name, e.g. userID for
+// user_id
+func paramIdent(name string) string {
+	ident := exportedIdent(name)
+	if ident == "" {
+		return "param"
+	}
+
+	runes := []rune(ident)
+	runes[0] = unicode.ToLower(runes[0])
+
+	ident = string(runes)
+	if strings.HasSuffix(ident, "Id") {
+		ident = ident[:len(ident)-2] + "ID"
+	}
+
+	if token.IsKeyword(ident) {
+		ident += "Param"
+	}
+
+	return ident
+}
+
+// uniqueIdent returns the ident, numbered if it's used in one of the sets,
+// and adds it to the first set
+func uniqueIdent(ident string, used ...map[string]bool) string {
+	isUsed := func(ident string) bool {
+		for _, set := range used {
+			if set[ident] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	unique := ident
+	for i := 2; isUsed(unique); i++ {
+		unique = ident + strconv.Itoa(i)
+	}
+
+	used[0][unique] = true
+
+	return unique
+}
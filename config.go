@@ -0,0 +1,675 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ConfigError is an error of a route file at a given position
+type ConfigError struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (err *ConfigError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", err.File, err.Line, err.Column, err.Msg)
+}
+
+// ConfigErrors is the list of errors found in a route file
+type ConfigErrors []*ConfigError
+
+func (errs ConfigErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// RouteDefinition is a route declared in a route file, as passed to
+// handler factories
+type RouteDefinition struct {
+	Method  string
+	Path    string
+	Options map[string]string
+}
+
+// OptionError is returned by handler factories when an option is missing
+// or invalid, so the error is reported at the position of the option
+type OptionError struct {
+	Key string
+	Msg string
+}
+
+func (err *OptionError) Error() string {
+	return fmt.Sprintf("option '%s' %s", err.Key, err.Msg)
+}
+
+// HandlerFactory builds the handler of a route declared in a route file
+// from its definition
+type HandlerFactory func(def RouteDefinition) (http.HandlerFunc, error)
+
+// Registry resolves the handler and middleware names used by route files
+type Registry struct {
+	handlers   map[string]http.HandlerFunc
+	factories  map[string]HandlerFactory
+	middleware map[string]Middleware
+}
+
+// NewRegistry returns a registry with the built-in handler factories:
+//
+//	redirect   redirects to the "to" option, with {param} placeholders
+//	           replaced by the escaped values, using the "code" option as
+//	           status (default 301). Targets starting with // are
+//	           rejected.
+//	static     serves files from the "root" option directory, the route
+//	           path must end with a catch-all param
+//	proxy      forwards requests to the "target" option URL, appending the
+//	           catch-all param if the route path has one
+func NewRegistry() *Registry {
+	reg := &Registry{
+		handlers:   make(map[string]http.HandlerFunc),
+		factories:  make(map[string]HandlerFactory),
+		middleware: make(map[string]Middleware),
+	}
+
+	reg.HandlerFactory("redirect", redirectFactory)
+	reg.HandlerFactory("static", staticFactory)
+	reg.HandlerFactory("proxy", proxyFactory)
+
+	return reg
+}
+
+// Handler registers a handler under the given name
+func (reg *Registry) Handler(name string, handler http.HandlerFunc) {
+	if handler == nil {
+		panic("handler must not be nil")
+	}
+
+	reg.handlers[name] = handler
+}
+
+// HandlerFactory registers a handler factory under the given name.
+// Handlers take precedence over factories with the same name.
+func (reg *Registry) HandlerFactory(name string, factory HandlerFactory) {
+	if factory == nil {
+		panic("factory must not be nil")
+	}
+
+	reg.factories[name] = factory
+}
+
+// Middleware registers a middleware under the given name
+func (reg *Registry) Middleware(name string, middleware Middleware) {
+	if middleware == nil {
+		panic("middleware must not be nil")
+	}
+
+	reg.middleware[name] = middleware
+}
+
+// LoadRoutesFile loads the routes declared in the given file.
+// Files with the .yaml or .yml extension are parsed as YAML, other files
+// as JSON. See LoadRoutes.
+func (router *Router) LoadRoutesFile(filename string, reg *Registry) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return router.LoadRoutes(filename, data, reg)
+}
+
+// LoadRoutes loads the routes declared in the given route file data.
+// The name is used in error messages and to pick the format: names with
+// the .yaml or .yml extension are parsed as YAML, other names as JSON.
+//
+// A route file contains a list of routes and groups, either at the top
+// level or under the "routes" key of a top-level object which may also
+// declare "middleware" and "options" applied to all routes:
+//
+//	{
+//	  "middleware": ["logging"],
+//	  "routes": [
+//	    {"method": "GET", "path": "/users/{id}", "handler": "users.show", "name": "showUser"},
+//	    {"method": "GET", "path": "/old", "handler": "redirect", "options": {"to": "/new"}},
+//	    {"group": "/admin", "middleware": ["auth"], "routes": [
+//	      {"method": "DELETE", "path": "/users/{id}", "handler": "users.delete"}
+//	    ]}
+//	  ]
+//	}
+//
+// Middleware are applied in order, the first one being the outermost.
+// Options of groups are inherited by their routes.
+//
+// Nothing is registered if the file has errors. All errors found are
+// returned as ConfigErrors, including the conflicts between routes, which
+// are detected exactly as on registration.
+func (router *Router) LoadRoutes(name string, data []byte, reg *Registry) error {
+	var (
+		root *confNode
+		err  error
+	)
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		root, err = parseYAMLConf(data)
+	default:
+		root, err = parseJSONConf(data)
+	}
+
+	if err != nil {
+		cerr := err.(*ConfigError)
+		cerr.File = name
+
+		return ConfigErrors{cerr}
+	}
+
+	l := &routeLoader{name: name, reg: reg}
+	l.loadRoot(root)
+
+	if len(l.errs) == 0 {
+		l.checkConflicts(router)
+	}
+
+	if len(l.errs) > 0 {
+		return l.errs
+	}
+
+	for _, def := range l.routes {
+		route := router.HandleRoute(def.method, def.path, def.handler)
+		if def.name != "" {
+			route.Name(def.name)
+		}
+	}
+
+	return nil
+}
+
+// loadedRoute is a route of a route file ready to be registered
+type loadedRoute struct {
+	method  string
+	path    string
+	name    string
+	handler http.HandlerFunc
+	node    *confNode
+}
+
+type routeLoader struct {
+	name   string
+	reg    *Registry
+	routes []*loadedRoute
+	errs   ConfigErrors
+}
+
+func (l *routeLoader) errorf(n *confNode, format string, args ...interface{}) {
+	l.errs = append(l.errs, &ConfigError{
+		File:   l.name,
+		Line:   n.line,
+		Column: n.col,
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *routeLoader) loadRoot(n *confNode) {
+	switch n.kind {
+	case confSequence:
+		l.loadEntries(n, "", nil, nil)
+	case confMapping:
+		l.loadGroup(n, "", nil, nil, false)
+	default:
+		l.errorf(n, "expected a list of routes or an object, got a %s", n.kindName())
+	}
+}
+
+// fields returns the values of a mapping by key, reporting unknown keys
+func (l *routeLoader) fields(n *confNode, allowed ...string) map[string]*confNode {
+	fields := make(map[string]*confNode)
+
+	for i, keyNode := range n.keys {
+		key, _ := keyNode.str()
+
+		known := false
+		for _, a := range allowed {
+			if a == key {
+				known = true
+				break
+			}
+		}
+
+		switch {
+		case !known:
+			l.errorf(keyNode, "unknown key '%s'", key)
+		case fields[key] != nil:
+			l.errorf(keyNode, "duplicated key '%s'", key)
+		default:
+			fields[key] = n.values[i]
+		}
+	}
+
+	return fields
+}
+
+func (l *routeLoader) loadEntries(n *confNode, prefix string, middleware []Middleware, options map[string]string) {
+	if n.kind != confSequence {
+		l.errorf(n, "expected a list of routes, got a %s", n.kindName())
+		return
+	}
+
+	for _, item := range n.items {
+		if item.kind != confMapping {
+			l.errorf(item, "expected a route object, got a %s", item.kindName())
+			continue
+		}
+
+		isGroup := false
+		for _, key := range item.keys {
+			if s, _ := key.str(); s == "group" {
+				isGroup = true
+			}
+		}
+
+		if isGroup {
+			l.loadGroup(item, prefix, middleware, options, true)
+		} else {
+			l.loadRoute(item, prefix, middleware, options)
+		}
+	}
+}
+
+func (l *routeLoader) loadGroup(n *confNode, prefix string, middleware []Middleware, options map[string]string, nested bool) {
+	allowed := []string{"middleware", "options", "routes"}
+	if nested {
+		allowed = append(allowed, "group")
+	}
+
+	fields := l.fields(n, allowed...)
+
+	if groupNode := fields["group"]; groupNode != nil {
+		group, ok := groupNode.str()
+
+		switch {
+		case !ok || !strings.HasPrefix(group, "/"):
+			l.errorf(groupNode, "group path must begin with '/'")
+			return
+		case group != "/" && strings.HasSuffix(group, "/"):
+			l.errorf(groupNode, "group path must not end with a trailing slash")
+			return
+		case group != "/":
+			prefix += group
+		}
+	}
+
+	middleware = l.middleware(fields["middleware"], middleware)
+	options = l.options(fields["options"], options)
+
+	if routes := fields["routes"]; routes != nil {
+		l.loadEntries(routes, prefix, middleware, options)
+	} else if nested {
+		l.errorf(n, "group without routes")
+	}
+}
+
+func (l *routeLoader) loadRoute(n *confNode, prefix string, middleware []Middleware, options map[string]string) {
+	fields := l.fields(n, "method", "path", "handler", "name", "middleware", "options")
+
+	route := &loadedRoute{node: n, method: http.MethodGet}
+
+	if methodNode := fields["method"]; methodNode != nil {
+		method, ok := methodNode.str()
+		if !ok || method == "" {
+			l.errorf(methodNode, "method must be a non-empty string")
+			return
+		}
+
+		route.method = strings.ToUpper(method)
+		if route.method == "ANY" {
+			route.method = MethodWild
+		}
+	}
+
+	pathNode := fields["path"]
+	if pathNode == nil {
+		l.errorf(n, "route without path")
+		return
+	}
+
+	path, ok := pathNode.str()
+	if !ok || !strings.HasPrefix(path, "/") {
+		l.errorf(pathNode, "path must begin with '/'")
+		return
+	}
+
+	if _, err := parsePattern(path); err != nil {
+		l.errorf(pathNode, "%v", err)
+		return
+	}
+
+	route.path = prefix + path
+
+	if nameNode := fields["name"]; nameNode != nil {
+		route.name, _ = nameNode.str()
+	}
+
+	middleware = l.middleware(fields["middleware"], middleware)
+	options = l.options(fields["options"], options)
+
+	handlerNode := fields["handler"]
+	if handlerNode == nil {
+		l.errorf(n, "route without handler")
+		return
+	}
+
+	handlerName, _ := handlerNode.str()
+	route.handler = l.handler(handlerNode, handlerName, fields["options"], RouteDefinition{
+		Method:  route.method,
+		Path:    route.path,
+		Options: options,
+	})
+
+	if route.handler == nil {
+		return
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		route.handler = middleware[i](route.handler)
+	}
+
+	l.routes = append(l.routes, route)
+}
+
+func (l *routeLoader) handler(n *confNode, name string, optionsNode *confNode, def RouteDefinition) http.HandlerFunc {
+	if handler := l.reg.handlers[name]; handler != nil {
+		return handler
+	}
+
+	factory := l.reg.factories[name]
+	if factory == nil {
+		l.errorf(n, "unknown handler '%s'", name)
+		return nil
+	}
+
+	handler, err := factory(def)
+	if err == nil {
+		return handler
+	}
+
+	// Report option errors at the position of the option, if declared by
+	// the route itself
+	if optErr, ok := err.(*OptionError); ok && optionsNode != nil && optionsNode.kind == confMapping {
+		for i, key := range optionsNode.keys {
+			if s, _ := key.str(); s == optErr.Key {
+				l.errorf(optionsNode.values[i], "handler '%s': %v", name, err)
+				return nil
+			}
+		}
+	}
+
+	l.errorf(n, "handler '%s': %v", name, err)
+
+	return nil
+}
+
+func (l *routeLoader) middleware(n *confNode, inherited []Middleware) []Middleware {
+	if n == nil {
+		return inherited
+	}
+
+	if n.kind != confSequence {
+		l.errorf(n, "expected a list of middleware names, got a %s", n.kindName())
+		return inherited
+	}
+
+	middleware := make([]Middleware, len(inherited), len(inherited)+len(n.items))
+	copy(middleware, inherited)
+
+	for _, item := range n.items {
+		name, _ := item.str()
+
+		m := l.reg.middleware[name]
+		if m == nil {
+			l.errorf(item, "unknown middleware '%s'", name)
+			continue
+		}
+
+		middleware = append(middleware, m)
+	}
+
+	return middleware
+}
+
+func (l *routeLoader) options(n *confNode, inherited map[string]string) map[string]string {
+	options := make(map[string]string, len(inherited))
+	for k, v := range inherited {
+		options[k] = v
+	}
+
+	if n == nil {
+		return options
+	}
+
+	if n.kind != confMapping {
+		l.errorf(n, "expected an options object, got a %s", n.kindName())
+		return options
+	}
+
+	for i, keyNode := range n.keys {
+		key, _ := keyNode.str()
+
+		value, ok := n.values[i].str()
+		if !ok {
+			l.errorf(n.values[i], "option '%s' must be a scalar", key)
+			continue
+		}
+
+		options[key] = value
+	}
+
+	return options
+}
+
+// checkConflicts registers the loaded routes on a scratch router, along
+// with the routes of the given router, to detect conflicts without
+// modifying it
+func (l *routeLoader) checkConflicts(router *Router) {
+	scratch := New()
+	scratch.CaseInsensitive = router.CaseInsensitive
+	scratch.UseEscapedPath = router.UseEscapedPath
+	scratch.CatchAllDotDot = router.CatchAllDotDot
+	scratch.Mutable(router.treeMutable)
+
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	// The paths of the registered routes are already translated
+	for _, route := range router.routes {
+		recoverPanic(func() { scratch.Handle(route.method, route.path, noop) })
+	}
+
+	scratch.Dialect = router.Dialect
+
+	names := make(map[string]bool)
+
+	for _, route := range l.routes {
+		if rcv := recoverPanic(func() { scratch.Handle(route.method, route.path, noop) }); rcv != nil {
+			l.errorf(route.node, "%v", rcv)
+		}
+
+		if route.name == "" {
+			continue
+		}
+
+		if names[route.name] || router.namedRoutes[route.name] != nil {
+			l.errorf(route.node, "a route named '%s' is already registered", route.name)
+		}
+
+		names[route.name] = true
+	}
+}
+
+func recoverPanic(fn func()) (rcv interface{}) {
+	defer func() {
+		rcv = recover()
+	}()
+
+	fn()
+
+	return nil
+}
+
+func redirectFactory(def RouteDefinition) (http.HandlerFunc, error) {
+	to := def.Options["to"]
+	if to == "" {
+		return nil, &OptionError{Key: "to", Msg: "is required"}
+	}
+
+	code := http.StatusMovedPermanently
+	if s, ok := def.Options["code"]; ok {
+		var err error
+		if code, err = strconv.Atoi(s); err != nil || code < 300 || code > 399 {
+			return nil, &OptionError{Key: "code", Msg: "must be a 3xx status code"}
+		}
+	}
+
+	if schemeRelative(to) {
+		return nil, &OptionError{Key: "to", Msg: "must not start with '//'"}
+	}
+
+	tokens, err := parsePattern(to)
+	if err != nil {
+		return nil, &OptionError{Key: "to", Msg: err.Error()}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		location := new(strings.Builder)
+
+		for _, tok := range tokens {
+			switch {
+			case tok.param == nil:
+				location.WriteString(tok.literal)
+			case tok.param.catchAll:
+				location.WriteString(escapeSegments(UserValue(r, tok.param.name)))
+			default:
+				location.WriteString(url.PathEscape(UserValue(r, tok.param.name)))
+			}
+		}
+
+		// The values must not turn the target into another host
+		if schemeRelative(location.String()) {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.RawQuery != "" && !strings.Contains(to, "?") {
+			location.WriteByte(questionMark)
+			location.WriteString(r.URL.RawQuery)
+		}
+
+		http.Redirect(w, r, location.String(), code)
+	}, nil
+}
+
+// schemeRelative reports whether the target is a relative URL of another
+// host, like //example.com, which browsers also accept with a backslash
+func schemeRelative(target string) bool {
+	return len(target) > 1 && target[0] == '/' && (target[1] == '/' || target[1] == '\\')
+}
+
+// escapeSegments escapes the segments of the value of a catch-all param
+func escapeSegments(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// catchAllParam returns the name of the catch-all param of the path, if any
+func catchAllParam(path string) string {
+	for _, param := range patternParams(path) {
+		if param.catchAll {
+			return param.name
+		}
+	}
+
+	return ""
+}
+
+func staticFactory(def RouteDefinition) (http.HandlerFunc, error) {
+	root := def.Options["root"]
+	if root == "" {
+		return nil, &OptionError{Key: "root", Msg: "is required"}
+	}
+
+	param := catchAllParam(def.Path)
+	if param == "" {
+		return nil, fmt.Errorf("path must end with a catch-all param, like '/{filepath:*}'")
+	}
+
+	fileServer := http.FileServer(http.Dir(root))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = "/" + UserValue(r, param)
+		r2.URL.RawPath = ""
+
+		fileServer.ServeHTTP(w, r2)
+	}, nil
+}
+
+func proxyFactory(def RouteDefinition) (http.HandlerFunc, error) {
+	target, err := url.Parse(def.Options["target"])
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		return nil, &OptionError{Key: "target", Msg: "must be an absolute URL"}
+	}
+
+	preserveHost := false
+	if s, ok := def.Options["preserve_host"]; ok {
+		if preserveHost, err = strconv.ParseBool(s); err != nil {
+			return nil, &OptionError{Key: "preserve_host", Msg: "must be a boolean"}
+		}
+	}
+
+	param := catchAllParam(def.Path)
+	proxy := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			path := target.Path
+			if param != "" {
+				path = strings.TrimSuffix(path, "/") + "/" + UserValue(r, param)
+			}
+
+			if path == "" {
+				path = "/"
+			}
+
+			r.URL.Scheme = target.Scheme
+			r.URL.Host = target.Host
+			r.URL.Path = path
+			r.URL.RawPath = ""
+
+			if target.RawQuery != "" && r.URL.RawQuery != "" {
+				r.URL.RawQuery = target.RawQuery + "&" + r.URL.RawQuery
+			} else if target.RawQuery != "" {
+				r.URL.RawQuery = target.RawQuery
+			}
+
+			if !preserveHost {
+				r.Host = target.Host
+			}
+		},
+	}
+
+	return proxy.ServeHTTP, nil
+}
@@ -0,0 +1,293 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testRegistry(calls *[]string) *Registry {
+	reg := NewRegistry()
+	reg.Handler("users.show", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, "show:"+UserValue(r, "id"))
+	})
+	reg.Handler("users.delete", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, "delete:"+UserValue(r, "id"))
+	})
+
+	for _, name := range []string{"logging", "auth"} {
+		name := name
+		reg.Middleware(name, func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				*calls = append(*calls, name)
+				next(w, r)
+			}
+		})
+	}
+
+	return reg
+}
+
+const testRoutesJSON = `{
+  "middleware": ["logging"],
+  "routes": [
+    {"method": "GET", "path": "/users/{id}", "handler": "users.show", "name": "showUser"},
+    {"method": "GET", "path": "/old/{id}", "handler": "redirect", "options": {"to": "/users/{id}", "code": 308}},
+    {"group": "/admin", "middleware": ["auth"], "routes": [
+      {"method": "DELETE", "path": "/users/{id}", "handler": "users.delete"}
+    ]}
+  ]
+}`
+
+const testRoutesYAML = `# routes
+middleware: [logging]
+routes:
+- method: GET
+  path: /users/{id}
+  handler: users.show
+  name: showUser
+- method: GET
+  path: "/old/{id}"
+  handler: redirect
+  options:
+    to: /users/{id}   # keep the id
+    code: 308
+- group: /admin
+  middleware:
+    - auth
+  routes:
+    - {method: DELETE, path: "/users/{id}", handler: users.delete}
+`
+
+func TestRouterLoadRoutes(t *testing.T) {
+	for name, data := range map[string]string{"routes.json": testRoutesJSON, "routes.yaml": testRoutesYAML} {
+		var calls []string
+
+		r := New()
+		if err := r.LoadRoutes(name, []byte(data), testRegistry(&calls)); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if route := r.NamedRoute("showUser"); route == nil || route.Path() != "/users/{id}" {
+			t.Errorf("%s: unexpected showUser route %v", name, route)
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/users/2", nil))
+
+		if got := strings.Join(calls, ","); got != "logging,show:1,logging,auth,delete:2" {
+			t.Errorf("%s: calls == %s", name, got)
+		}
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/old/3?x=1", nil))
+
+		if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != "/users/3?x=1" {
+			t.Errorf("%s: redirect == %d %s", name, w.Code, w.Header().Get("Location"))
+		}
+	}
+}
+
+func TestRouterLoadRoutesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		errs []string
+	}{
+		{
+			"syntax.json",
+			"{\n  \"routes\": [\n    {\"path\": \"/a\",}\n  ]\n}",
+			[]string{"syntax.json:3:19: invalid character ',' looking for beginning of value"},
+		},
+		{
+			"resolve.json",
+			`[
+  {"path": "/a", "handler": "nope"},
+  {"path": "/b", "handler": "users.show", "middleware": ["auth", "nope"]},
+  {"path": "/c", "handler": "redirect", "options": {"code": 200}},
+  {"path": "/d", "handler": "redirect", "options": {"to": "/x", "code": 200}},
+  {"path": "/e", "handler": "users.show", "verb": "GET"}
+]`,
+			[]string{
+				"resolve.json:2:29: unknown handler 'nope'",
+				"resolve.json:3:66: unknown middleware 'nope'",
+				"resolve.json:4:29: handler 'redirect': option 'to' is required",
+				"resolve.json:5:73: handler 'redirect': option 'code' must be a 3xx status code",
+				"resolve.json:6:43: unknown key 'verb'",
+			},
+		},
+		{
+			"conflict.yaml",
+			`- path: /users/{id}
+  handler: users.show
+- group: /x
+  routes:
+    - path: /users/{name}
+      handler: users.show
+- path: /existing
+  handler: users.show
+- path: /users/{name}
+  handler: users.show
+`,
+			[]string{
+				"conflict.yaml:7:3: a handler is already registered for path '/existing'",
+				"conflict.yaml:9:3: path '/users/{name}' conflicts with the registered path '/users/{id}'",
+			},
+		},
+		{
+			"indent.yaml",
+			"routes:\n  - path: /a\n     handler: x\n",
+			[]string{"indent.yaml:3:6: unexpected indentation"},
+		},
+	}
+
+	for _, test := range tests {
+		var calls []string
+
+		r := New()
+		r.GET("/existing", func(w http.ResponseWriter, r *http.Request) {})
+
+		err := r.LoadRoutes(test.name, []byte(test.data), testRegistry(&calls))
+
+		errs, ok := err.(ConfigErrors)
+		if !ok {
+			t.Errorf("%s: error == %v, want ConfigErrors", test.name, err)
+			continue
+		}
+
+		got := strings.Split(errs.Error(), "\n")
+		if fmt.Sprint(got) != fmt.Sprint(test.errs) {
+			t.Errorf("%s: errors ==\n%s\nwant\n%s", test.name, strings.Join(got, "\n"), strings.Join(test.errs, "\n"))
+		}
+
+		if len(r.Routes()) != 1 {
+			t.Errorf("%s: routes registered despite the errors", test.name)
+		}
+	}
+}
+
+func TestRouterLoadRoutesSettings(t *testing.T) {
+	var calls []string
+
+	r := New()
+	r.Dialect = HTTPRouterDialect
+	r.GET("/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+
+	// The loaded patterns are translated like the registered ones
+	err := r.LoadRoutes("dialect.yaml", []byte("- path: /users/:name\n  handler: users.show\n"), testRegistry(&calls))
+
+	want := "dialect.yaml:1:3: path '/users/{name}' conflicts with the registered path '/users/{id}'"
+	if err == nil || err.Error() != want {
+		t.Errorf("error == %v, want %s", err, want)
+	}
+
+	if err := r.LoadRoutes("dialect.yaml", []byte("- path: /posts/:id\n  handler: users.show\n"), testRegistry(&calls)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if route := r.Routes()[1]; route.Path() != "/posts/{id}" {
+		t.Errorf("path == %s, want /posts/{id}", route.Path())
+	}
+
+	// Mutable routers accept the routes overriding the registered ones
+	mutable := New()
+	mutable.Mutable(true)
+	mutable.GET("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	if err := mutable.LoadRoutes("override.yaml", []byte("- path: /users/{id}\n  handler: users.show\n"), testRegistry(&calls)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	calls = nil
+	mutable.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	if got := strings.Join(calls, ","); got != "show:1" {
+		t.Errorf("calls == %s, want the loaded handler", got)
+	}
+}
+
+func TestRouterLoadRoutesStatic(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	routes := fmt.Sprintf(`[{"path": "/assets/{filepath:*}", "handler": "static", "options": {"root": %q}}]`, dir)
+
+	r := New()
+	if err := r.LoadRoutes("static.json", []byte(routes), NewRegistry()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets/a.txt", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != "hello" {
+		t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestRouterLoadRoutesRedirect(t *testing.T) {
+	routes := `[
+  {"path": "/r/{p:*}", "handler": "redirect", "options": {"to": "/{p:*}"}},
+  {"path": "/u/{name}", "handler": "redirect", "options": {"to": "/users/{name}"}}
+]`
+
+	r := New()
+	if err := r.LoadRoutes("redirect.json", []byte(routes), NewRegistry()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		code     int
+		location string
+	}{
+		{"/r/a/b%20c", http.StatusMovedPermanently, "/a/b%20c"},
+		{"/r//evil.com", http.StatusBadRequest, ""},
+		{"/r/%5Cevil.com", http.StatusMovedPermanently, "/%5Cevil.com"},
+		{"/u/a%3Fb", http.StatusMovedPermanently, "/users/a%3Fb"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if w.Code != test.code || w.Header().Get("Location") != test.location {
+			t.Errorf("%s: redirect == %d %s, want %d %s", test.path, w.Code, w.Header().Get("Location"), test.code, test.location)
+		}
+	}
+
+	err := r.LoadRoutes("redirect.json", []byte(`[{"path": "/x", "handler": "redirect", "options": {"to": "//evil.com"}}]`), NewRegistry())
+
+	want := "redirect.json:1:58: handler 'redirect': option 'to' must not start with '//'"
+	if err == nil || err.Error() != want {
+		t.Errorf("error == %v, want %s", err, want)
+	}
+}
+
+func TestRouterLoadRoutesProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery)
+	}))
+	defer backend.Close()
+
+	routes := fmt.Sprintf(`[{"method": "ANY", "path": "/api/{rest:*}", "handler": "proxy", "options": {"target": "%s/v2"}}]`, backend.URL)
+
+	r := New()
+	if err := r.LoadRoutes("proxy.json", []byte(routes), NewRegistry()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/users/1?x=y", nil))
+
+	if got := w.Body.String(); got != "POST /v2/users/1?x=y" {
+		t.Errorf("unexpected response %q", got)
+	}
+}
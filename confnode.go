@@ -0,0 +1,555 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type confKind uint8
+
+const (
+	confScalar confKind = iota
+	confMapping
+	confSequence
+)
+
+// confNode is a value of a route file with its position, so errors can be
+// reported precisely
+type confNode struct {
+	kind confKind
+	line int
+	col  int
+
+	// scalar value: string, json.Number, bool or nil
+	value interface{}
+
+	// mapping keys (scalar nodes) and values
+	keys   []*confNode
+	values []*confNode
+
+	// sequence items
+	items []*confNode
+}
+
+var confNumberRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+func (n *confNode) kindName() string {
+	switch n.kind {
+	case confMapping:
+		return "mapping"
+	case confSequence:
+		return "sequence"
+	}
+
+	return "scalar"
+}
+
+// str returns the value of a scalar node as string
+func (n *confNode) str() (string, bool) {
+	if n.kind != confScalar {
+		return "", false
+	}
+
+	switch v := n.value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case nil:
+		return "", true
+	}
+
+	return fmt.Sprint(n.value), true
+}
+
+// parseJSONConf parses a JSON document keeping the position of the values
+func parseJSONConf(data []byte) (*confNode, error) {
+	p := &jsonConfParser{
+		data: data,
+		dec:  json.NewDecoder(bytes.NewReader(data)),
+	}
+	p.dec.UseNumber()
+
+	n, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.dec.Token(); err != io.EOF {
+		line, col := p.position()
+		return nil, &ConfigError{Line: line, Column: col, Msg: "unexpected data after the top-level value"}
+	}
+
+	return n, nil
+}
+
+type jsonConfParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// position returns the line and column of the next token
+func (p *jsonConfParser) position() (int, int) {
+	offset := int(p.dec.InputOffset())
+
+	for offset < len(p.data) {
+		switch p.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+			continue
+		}
+
+		break
+	}
+
+	return offsetPosition(p.data, offset)
+}
+
+func (p *jsonConfParser) parse() (*confNode, error) {
+	line, col := p.position()
+
+	tok, err := p.dec.Token()
+	if err != nil {
+		if serr, ok := err.(*json.SyntaxError); ok {
+			line, col = offsetPosition(p.data, int(serr.Offset))
+		}
+
+		return nil, &ConfigError{Line: line, Column: col, Msg: err.Error()}
+	}
+
+	n := &confNode{line: line, col: col}
+
+	switch tok {
+	case json.Delim('{'):
+		n.kind = confMapping
+
+		for p.dec.More() {
+			key, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+
+			n.keys = append(n.keys, key)
+			n.values = append(n.values, value)
+		}
+
+		_, err = p.dec.Token()
+
+	case json.Delim('['):
+		n.kind = confSequence
+
+		for p.dec.More() {
+			item, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+
+			n.items = append(n.items, item)
+		}
+
+		_, err = p.dec.Token()
+
+	default:
+		n.value = tok
+	}
+
+	if err != nil {
+		line, col := p.position()
+		return nil, &ConfigError{Line: line, Column: col, Msg: err.Error()}
+	}
+
+	return n, nil
+}
+
+func offsetPosition(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	line := 1 + bytes.Count(data[:offset], []byte{'\n'})
+	col := offset - bytes.LastIndexByte(data[:offset], '\n')
+
+	return line, col
+}
+
+// yamlLine is a non-empty line of a YAML document without comment
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// parseYAMLConf parses the subset of YAML used by route files: block
+// mappings and sequences, plain and quoted scalars, flow sequences and
+// mappings of scalars, and comments.
+// Anchors, tags, multi-line scalars and multiple documents are not supported.
+func parseYAMLConf(data []byte) (*confNode, error) {
+	p := new(yamlConfParser)
+
+	for i, text := range strings.Split(string(data), "\n") {
+		text = strings.TrimRight(stripYAMLComment(text), " \t\r")
+
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || (len(p.lines) == 0 && trimmed == "---") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, &ConfigError{Line: i + 1, Column: len(text) - len(trimmed) + 1, Msg: "tabs are not allowed for indentation"}
+		}
+
+		p.lines = append(p.lines, &yamlLine{num: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+
+	if len(p.lines) == 0 {
+		return &confNode{kind: confScalar, line: 1, col: 1}, nil
+	}
+
+	n, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, p.errorf(p.lines[p.pos], 0, "unexpected indentation")
+	}
+
+	return n, nil
+}
+
+type yamlConfParser struct {
+	lines []*yamlLine
+	pos   int
+}
+
+func (p *yamlConfParser) errorf(l *yamlLine, offset int, format string, args ...interface{}) error {
+	return &ConfigError{Line: l.num, Column: l.indent + offset + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *yamlConfParser) parseBlock(indent int) (*confNode, error) {
+	l := p.lines[p.pos]
+
+	if isYAMLSeqItem(l.text) {
+		return p.parseSequence(indent)
+	}
+
+	if _, _, ok := splitYAMLKey(l.text); ok {
+		return p.parseMapping(indent)
+	}
+
+	p.pos++
+
+	return parseYAMLValue(l, 0)
+}
+
+func (p *yamlConfParser) parseSequence(indent int) (*confNode, error) {
+	first := p.lines[p.pos]
+	n := &confNode{kind: confSequence, line: first.num, col: first.indent + 1}
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		} else if l.indent > indent {
+			return nil, p.errorf(l, 0, "unexpected indentation")
+		} else if !isYAMLSeqItem(l.text) {
+			break
+		}
+
+		rest := strings.TrimLeft(l.text[1:], " ")
+		offset := len(l.text) - len(rest)
+
+		if rest == "" {
+			p.pos++
+
+			if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+				n.items = append(n.items, &confNode{kind: confScalar, line: l.num, col: l.indent + 1})
+				continue
+			}
+
+			item, err := p.parseBlock(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+
+			n.items = append(n.items, item)
+
+			continue
+		}
+
+		// Parse the rest of the line as if it were a line on its own, so
+		// that "- key: value" starts a mapping
+		p.lines[p.pos] = &yamlLine{num: l.num, indent: l.indent + offset, text: rest}
+
+		item, err := p.parseBlock(l.indent + offset)
+		if err != nil {
+			return nil, err
+		}
+
+		n.items = append(n.items, item)
+	}
+
+	return n, nil
+}
+
+func (p *yamlConfParser) parseMapping(indent int) (*confNode, error) {
+	first := p.lines[p.pos]
+	n := &confNode{kind: confMapping, line: first.num, col: first.indent + 1}
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		} else if l.indent > indent {
+			return nil, p.errorf(l, 0, "unexpected indentation")
+		}
+
+		key, value, ok := splitYAMLKey(l.text)
+		if !ok {
+			return nil, p.errorf(l, 0, "expected a 'key: value' pair")
+		}
+
+		keyNode, err := parseYAMLScalar(key, l.num, l.indent+1)
+		if err != nil {
+			return nil, err
+		}
+
+		p.pos++
+
+		var valueNode *confNode
+
+		switch {
+		case value != "":
+			valueNode, err = parseYAMLValue(l, len(l.text)-len(value))
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			valueNode, err = p.parseBlock(p.lines[p.pos].indent)
+		case p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSeqItem(p.lines[p.pos].text):
+			// Sequences may be at the same indentation as their key
+			valueNode, err = p.parseSequence(indent)
+		default:
+			valueNode = &confNode{kind: confScalar, line: l.num, col: l.indent + len(l.text) + 1}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		n.keys = append(n.keys, keyNode)
+		n.values = append(n.values, valueNode)
+	}
+
+	return n, nil
+}
+
+// parseYAMLValue parses the inline value starting at the given offset of
+// the line
+func parseYAMLValue(l *yamlLine, offset int) (*confNode, error) {
+	text := l.text[offset:]
+	col := l.indent + offset + 1
+
+	if len(text) > 0 && (text[0] == '[' || text[0] == '{') {
+		return parseYAMLFlow(text, l.num, col)
+	}
+
+	return parseYAMLScalar(text, l.num, col)
+}
+
+func parseYAMLFlow(text string, line, col int) (*confNode, error) {
+	open, close := text[0], byte(']')
+	kind := confSequence
+
+	if open == '{' {
+		close = '}'
+		kind = confMapping
+	}
+
+	if text[len(text)-1] != close {
+		return nil, &ConfigError{Line: line, Column: col, Msg: fmt.Sprintf("unclosed '%c'", open)}
+	}
+
+	n := &confNode{kind: kind, line: line, col: col}
+
+	for _, part := range splitYAMLFlow(text[1:len(text)-1], col+1) {
+		if strings.ContainsAny(part.text[:1], "[{") {
+			return nil, &ConfigError{Line: line, Column: part.col, Msg: "nested flow collections are not supported"}
+		}
+
+		if kind == confSequence {
+			item, err := parseYAMLScalar(part.text, line, part.col)
+			if err != nil {
+				return nil, err
+			}
+
+			n.items = append(n.items, item)
+
+			continue
+		}
+
+		key, value, ok := splitYAMLKey(part.text)
+		if !ok {
+			return nil, &ConfigError{Line: line, Column: part.col, Msg: "expected a 'key: value' pair"}
+		}
+
+		keyNode, err := parseYAMLScalar(key, line, part.col)
+		if err != nil {
+			return nil, err
+		}
+
+		valueNode, err := parseYAMLScalar(value, line, part.col+len(part.text)-len(value))
+		if err != nil {
+			return nil, err
+		}
+
+		n.keys = append(n.keys, keyNode)
+		n.values = append(n.values, valueNode)
+	}
+
+	return n, nil
+}
+
+type yamlFlowPart struct {
+	text string
+	col  int
+}
+
+// splitYAMLFlow splits the content of a flow collection by commas outside
+// of quotes
+func splitYAMLFlow(text string, col int) []yamlFlowPart {
+	parts := make([]yamlFlowPart, 0)
+
+	var quote byte
+	start := 0
+
+	add := func(end int) {
+		part := strings.TrimSpace(text[start:end])
+		if part != "" {
+			lead := len(text[start:end]) - len(strings.TrimLeft(text[start:end], " "))
+			parts = append(parts, yamlFlowPart{text: part, col: col + start + lead})
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			add(i)
+			start = i + 1
+		}
+	}
+
+	add(len(text))
+
+	return parts
+}
+
+func parseYAMLScalar(text string, line, col int) (*confNode, error) {
+	n := &confNode{kind: confScalar, line: line, col: col}
+
+	switch {
+	case strings.HasPrefix(text, `"`):
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, &ConfigError{Line: line, Column: col, Msg: "invalid double-quoted string"}
+		}
+
+		n.value = s
+
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, &ConfigError{Line: line, Column: col, Msg: "invalid single-quoted string"}
+		}
+
+		n.value = strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+
+	case text == "", text == "~", text == "null":
+		n.value = nil
+
+	case text == "true", text == "false":
+		n.value = text == "true"
+
+	case confNumberRe.MatchString(text):
+		n.value = json.Number(text)
+
+	default:
+		if strings.ContainsAny(text[:1], "&*!|>%@`") {
+			return nil, &ConfigError{Line: line, Column: col, Msg: fmt.Sprintf("unsupported YAML syntax '%c'", text[0])}
+		}
+
+		n.value = text
+	}
+
+	return n, nil
+}
+
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits a "key: value" line outside of quotes
+func splitYAMLKey(text string) (string, string, bool) {
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		case c == '[' || c == '{':
+			if i == 0 {
+				return "", "", false
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// stripYAMLComment removes a trailing comment outside of quotes
+func stripYAMLComment(text string) string {
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || text[i-1] == ' ' || text[i-1] == '[' || text[i-1] == ',' || text[i-1] == '-' {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,100 @@
+package router
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// confValue converts a node to plain values for comparison
+func confValue(n *confNode) interface{} {
+	switch n.kind {
+	case confMapping:
+		m := make(map[string]interface{})
+		for i, key := range n.keys {
+			k, _ := key.str()
+			m[k] = confValue(n.values[i])
+		}
+
+		return m
+	case confSequence:
+		items := make([]interface{}, len(n.items))
+		for i, item := range n.items {
+			items[i] = confValue(item)
+		}
+
+		return items
+	}
+
+	return n.value
+}
+
+func TestParseYAMLConf(t *testing.T) {
+	data := `---
+# comment
+a: 1
+b: "two # not a comment"
+c: 'it''s'
+d:
+  - x
+  - y: true
+    z: ~
+  -
+    - nested
+e: [1, "a, b", c]
+f: {k: v}
+g:
+h: -1.5e3
+`
+
+	n, err := parseYAMLConf([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"a": json.Number("1"),
+		"b": "two # not a comment",
+		"c": "it's",
+		"d": []interface{}{
+			"x",
+			map[string]interface{}{"y": true, "z": nil},
+			[]interface{}{"nested"},
+		},
+		"e": []interface{}{json.Number("1"), "a, b", "c"},
+		"f": map[string]interface{}{"k": "v"},
+		"g": nil,
+		"h": json.Number("-1.5e3"),
+	}
+
+	if got := confValue(n); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAMLConf() == %#v, want %#v", got, want)
+	}
+
+	y := n.values[3].items[1].values[0]
+	if y.line != 8 || y.col != 8 {
+		t.Errorf("position of d[1].y == %d:%d, want 8:8", y.line, y.col)
+	}
+
+	for _, invalid := range []string{"a: *ref\n", "a: [1, [2]]\n", "a: 1\n  b: 2\n", "a: \"x\n", "- a\nb: 1\n"} {
+		if _, err := parseYAMLConf([]byte(invalid)); err == nil {
+			t.Errorf("parseYAMLConf(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestParseJSONConf(t *testing.T) {
+	n, err := parseJSONConf([]byte("{\n  \"a\": [1, {\"b\": null}]\n}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b := n.values[0].items[1].values[0]
+	if b.line != 2 || b.col != 18 || b.value != nil {
+		t.Errorf("b == %+v, want null at 2:18", b)
+	}
+
+	if _, err := parseJSONConf([]byte(`{} []`)); err == nil {
+		t.Error("expected an error with trailing data")
+	}
+}
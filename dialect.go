@@ -0,0 +1,149 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pedia/router/radix"
+)
+
+// Dialect translates the route patterns of another router to the syntax of
+// this router
+type Dialect func(path string) string
+
+// Params are the params of a request in path order, like the params of
+// httprouter
+type Params = radix.Params
+
+// ParamsHandler is a request handler reading the params of the request
+// like the handlers of httprouter
+type ParamsHandler func(w http.ResponseWriter, r *http.Request, ps Params)
+
+// HTTPRouterDialect translates the patterns of httprouter, where :name
+// matches a segment and *name the rest of the path.
+// The value of *name read with UserValue has no leading slash, unlike in
+// httprouter, the Params of HandleParams have it.
+func HTTPRouterDialect(path string) string {
+	return translateDialect(path, true, true)
+}
+
+// EchoDialect translates the patterns of echo, where :name matches a
+// segment and * the rest of the path, read as the param "*"
+func EchoDialect(path string) string {
+	return translateDialect(path, true, false)
+}
+
+// ChiDialect translates the patterns of chi, where {name} and
+// {name:regex} match a segment like in this router and * matches the rest
+// of the path, read as the param "*"
+func ChiDialect(path string) string {
+	return translateDialect(path, false, false)
+}
+
+// translateDialect translates the :name params, if enabled, and the
+// catch-all params, named after the star if namedStar or "*" otherwise.
+// The params in braces are kept as is.
+func translateDialect(path string, colon, namedStar bool) string {
+	var b strings.Builder
+
+	depth := 0
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+
+		switch {
+		case c == '{':
+			depth++
+		case c == '}' && depth > 0:
+			depth--
+		case depth > 0:
+		case c == ':' && colon:
+			end := segmentEnd(path, i+1)
+			b.WriteString("{" + path[i+1:end] + "}")
+			i = end - 1
+
+			continue
+		case c == '*':
+			end, name := i+1, "*"
+			if namedStar {
+				end = segmentEnd(path, i+1)
+				name = path[i+1 : end]
+			}
+
+			b.WriteString("{" + name + ":*}")
+			i = end - 1
+
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// segmentEnd returns the index of the end of the segment starting at the
+// given index
+func segmentEnd(path string, start int) int {
+	if i := strings.IndexByte(path[start:], '/'); i != -1 {
+		return start + i
+	}
+
+	return len(path)
+}
+
+// HandleParams registers a new request handler reading the params like
+// the handlers of httprouter, where the values of the catch-all params
+// start with a slash. See Router.Handle.
+func (router *Router) HandleParams(method, path string, handler ParamsHandler) *Route {
+	if handler == nil {
+		panic("handler must not be nil")
+	}
+
+	params := patternParams(router.translatePath(path))
+
+	return router.handle(&Route{method: method, path: path, handler: func(w http.ResponseWriter, r *http.Request) {
+		values := UserValues(r)
+		ps := make(Params, 0, len(params))
+
+		for _, param := range params {
+			value, ok := values[param.name]
+			if !ok {
+				continue
+			}
+
+			if param.catchAll {
+				value = "/" + value
+			}
+
+			ps = append(ps, radix.Param{Key: param.name, Value: value})
+		}
+
+		handler(w, r, ps)
+	}})
+}
+
+// HandleParams registers a new request handler reading the params like
+// the handlers of httprouter. See Router.HandleParams.
+func (g *Group) HandleParams(method, path string, handler ParamsHandler) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.HandleParams(method, g.prefix+path, handler)
+}
+
+// translatePath translates the path with the dialect of the router, if any
+func (router *Router) translatePath(path string) string {
+	if router.Dialect == nil {
+		return path
+	}
+
+	return router.Dialect(path)
+}
+
+// URLParam returns the value of the param of the request, like
+// chi.URLParam. It's the same as UserValue.
+func URLParam(r *http.Request, key string) string {
+	return UserValue(r, key)
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDialects(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		path    string
+		want    string
+	}{
+		{HTTPRouterDialect, "/users/:id", "/users/{id}"},
+		{HTTPRouterDialect, "/users/:id/posts/:post", "/users/{id}/posts/{post}"},
+		{HTTPRouterDialect, "/static/*filepath", "/static/{filepath:*}"},
+		{HTTPRouterDialect, "/items/{id:[0-9]+}", "/items/{id:[0-9]+}"},
+		{HTTPRouterDialect, "/time/{t:[0-9]{2}:[0-9]{2}}", "/time/{t:[0-9]{2}:[0-9]{2}}"},
+		{EchoDialect, "/users/:id", "/users/{id}"},
+		{EchoDialect, "/static/*", "/static/{*:*}"},
+		{ChiDialect, "/users/{id:[0-9]+}/*", "/users/{id:[0-9]+}/{*:*}"},
+		{ChiDialect, "/a:b", "/a:b"},
+	}
+
+	for _, test := range tests {
+		if got := test.dialect(test.path); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.path, got, test.want)
+		}
+
+		if got := test.dialect(test.want); got != test.want {
+			t.Errorf("%s: translated again to %q", test.want, got)
+		}
+	}
+}
+
+func TestRouterDialect(t *testing.T) {
+	r := New()
+	r.Dialect = HTTPRouterDialect
+
+	r.HandleParams(http.MethodGet, "/users/:id/posts/:post", func(w http.ResponseWriter, r *http.Request, ps Params) {
+		w.Write([]byte(ps.ByName("id") + " " + ps.ByName("post") + " " + ps[0].Key))
+	})
+	r.GET("/static/*filepath", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(UserValue(r, "filepath")))
+	})
+	r.HandleParams(http.MethodGet, "/files/*filepath", func(w http.ResponseWriter, r *http.Request, ps Params) {
+		w.Write([]byte(ps.ByName("filepath")))
+	})
+
+	chi := New()
+	chi.Dialect = ChiDialect
+
+	chi.Group("/api").GET("/files/*", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(URLParam(r, "*")))
+	})
+
+	tests := []struct {
+		router *Router
+		url    string
+		body   string
+	}{
+		{r, "/users/7/posts/9", "7 9 id"},
+		{r, "/static/css/site.css", "css/site.css"},
+		// Like in httprouter
+		{r, "/files/css/site.css", "/css/site.css"},
+		{r, "/files/", "/"},
+		{chi, "/api/files/a/b.txt", "a/b.txt"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		test.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.url, nil))
+
+		if w.Body.String() != test.body {
+			t.Errorf("%s: body %q, want %q", test.url, w.Body, test.body)
+		}
+	}
+
+	if got := r.Routes()[0].Path(); got != "/users/{id}/posts/{post}" {
+		t.Errorf("route path %q, want the translated path", got)
+	}
+}
@@ -0,0 +1,161 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// endpoint holds the routes registered with the same method and path.
+// When several routes are registered, or when a route is a variant (e.g.
+// registered for an API version), the endpoint selects the route serving
+// each request.
+type endpoint struct {
+	router *Router
+	method string
+	path   string
+	routes []*Route
+
+	// Method tables of the variants of the path
+	tables []*methodTable
+}
+
+// dispatched reports whether the route must be selected per request
+func (ep *endpoint) dispatched() bool {
+	return len(ep.routes) > 1 || ep.routes[0].isVariant()
+}
+
+// hasPredicates reports whether some routes of the endpoint have predicates
+func (ep *endpoint) hasPredicates() bool {
+	for _, route := range ep.routes {
+		if len(route.predicates) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handler returns the handler to store in the method tables.
+// If there is nothing to select, the handler of the route is stored as is.
+func (ep *endpoint) handler() http.HandlerFunc {
+	if !ep.dispatched() {
+		return ep.routes[0].handler
+	}
+
+	return ep.serve
+}
+
+// add adds a route to the endpoint.
+// If the router is mutable and a route of the same variant is already
+// registered, its handler is updated and the existing route is returned.
+func (ep *endpoint) add(route *Route) *Route {
+	for _, existing := range ep.routes {
+		if !existing.sameVariant(route) {
+			continue
+		}
+
+		if !ep.router.treeMutable {
+			if variant := route.variant(); variant != "" {
+				panic(fmt.Sprintf("a handler for %s is already registered for path '%s'", variant, ep.path))
+			}
+
+			panic(fmt.Sprintf("a handler is already registered for path '%s'", ep.path))
+		}
+
+		existing.handler = route.handler
+		ep.router.updateTree(ep)
+
+		return existing
+	}
+
+	ep.routes = append(ep.routes, route)
+	ep.router.updateTree(ep)
+
+	return nil
+}
+
+// candidates returns the routes which can serve the request: the routes
+// with the same predicates as the first matching route with predicates, or
+// the routes without predicates
+func (ep *endpoint) candidates(r *http.Request) []*Route {
+	var first *Route
+
+	for _, route := range ep.routes {
+		if len(route.predicates) > 0 && route.match(r) {
+			first = route
+			break
+		}
+	}
+
+	candidates := make([]*Route, 0, len(ep.routes))
+
+	for _, route := range ep.routes {
+		switch {
+		case first == nil && len(route.predicates) == 0,
+			first != nil && (route == first || (route.samePredicates(first) && route.match(r))):
+			candidates = append(candidates, route)
+		}
+	}
+
+	return candidates
+}
+
+func (ep *endpoint) serve(w http.ResponseWriter, r *http.Request) {
+	candidates := ep.candidates(r)
+
+	if len(candidates) == 0 {
+		ep.router.unmatched(w, r)
+		return
+	}
+
+	candidates, r = ep.selectMedia(w, r, candidates)
+
+	route := ep.selectVersion(w, r, candidates)
+	if route == nil {
+		ep.router.notAcceptable(w, r, ep.path)
+		return
+	}
+
+	route.handler(w, r)
+}
+
+// isVariant reports whether the route only serves some requests of its
+// method and path
+func (route *Route) isVariant() bool {
+	return route.version != "" || route.mediaType != "" || len(route.predicates) > 0
+}
+
+// sameVariant reports whether both routes serve the same requests
+func (route *Route) sameVariant(other *Route) bool {
+	return route.version == other.version && route.mediaType == other.mediaType && route.samePredicates(other)
+}
+
+// variant describes the requests served by the route, for error messages
+func (route *Route) variant() string {
+	variants := make([]string, 0, 3)
+
+	if route.version != "" {
+		variants = append(variants, "version '"+route.version+"'")
+	}
+
+	if route.mediaType != "" {
+		variants = append(variants, "media type '"+route.mediaType+"'")
+	}
+
+	if len(route.predicates) > 0 {
+		variants = append(variants, "predicates '"+route.describePredicates()+"'")
+	}
+
+	return strings.Join(variants, " and ")
+}
+
+func (router *Router) notAcceptable(w http.ResponseWriter, r *http.Request, path string) {
+	if router.NotAcceptable != nil {
+		router.NotAcceptable(w, r)
+	} else {
+		router.writeProblem(w, r, http.StatusNotAcceptable, "", map[string]interface{}{
+			"pattern": path,
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// HandlerFuncE is a handler returning an error, which is handled by the
+// ErrorHandler of the router or of the group of the route
+type HandlerFuncE func(http.ResponseWriter, *http.Request) error
+
+// ErrorHandler replies to the requests whose handler failed
+type ErrorHandler func(http.ResponseWriter, *http.Request, error)
+
+// HTTPError is an error with the HTTP status code of its response
+type HTTPError interface {
+	error
+	StatusCode() int
+}
+
+// StatusError is a HTTPError wrapping an error
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+// NewHTTPError returns a HTTPError with the given status code and message.
+// If the message is empty, the status text is used.
+func NewHTTPError(code int, message string) *StatusError {
+	if message == "" {
+		message = http.StatusText(code)
+	}
+
+	return &StatusError{Code: code, Err: errors.New(message)}
+}
+
+func (e *StatusError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+// StatusCode returns the status code of the error
+func (e *StatusError) StatusCode() int {
+	return e.Code
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error handled when a handler panics and the
+// PanicHandler of the router is not set
+type PanicError struct {
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// StatusCode returns 500 Internal Server Error
+func (e *PanicError) StatusCode() int {
+	return http.StatusInternalServerError
+}
+
+// ErrorHandler overrides Router.ErrorHandler for the routes of the group and
+// its sub-groups.
+// It must be set before registering the routes.
+func (g *Group) ErrorHandler(h ErrorHandler) *Group {
+	g.errorHandler = h
+
+	if h != nil {
+		g.router.errorGroups = true
+	}
+
+	return g
+}
+
+// HandleE registers a new request handler returning an error with the given
+// path and method, and returns the registered route
+func (router *Router) HandleE(method, path string, handler HandlerFuncE) *Route {
+	route := &Route{method: method, path: path}
+	route.handler = router.errorFunc(route, handler)
+
+	return router.handle(route)
+}
+
+// GETE is a shortcut for router.HandleE(http.MethodGet, path, handler)
+func (router *Router) GETE(path string, handler HandlerFuncE) {
+	router.HandleE(http.MethodGet, path, handler)
+}
+
+// HEADE is a shortcut for router.HandleE(http.MethodHead, path, handler)
+func (router *Router) HEADE(path string, handler HandlerFuncE) {
+	router.HandleE(http.MethodHead, path, handler)
+}
+
+// POSTE is a shortcut for router.HandleE(http.MethodPost, path, handler)
+func (router *Router) POSTE(path string, handler HandlerFuncE) {
+	router.HandleE(http.MethodPost, path, handler)
+}
+
+// PUTE is a shortcut for router.HandleE(http.MethodPut, path, handler)
+func (router *Router) PUTE(path string, handler HandlerFuncE) {
+	router.HandleE(http.MethodPut, path, handler)
+}
+
+// PATCHE is a shortcut for router.HandleE(http.MethodPatch, path, handler)
+func (router *Router) PATCHE(path string, handler HandlerFuncE) {
+	router.HandleE(http.MethodPatch, path, handler)
+}
+
+// DELETEE is a shortcut for router.HandleE(http.MethodDelete, path, handler)
+func (router *Router) DELETEE(path string, handler HandlerFuncE) {
+	router.HandleE(http.MethodDelete, path, handler)
+}
+
+// ANYE is a shortcut for router.HandleE(router.MethodWild, path, handler)
+func (router *Router) ANYE(path string, handler HandlerFuncE) {
+	router.HandleE(MethodWild, path, handler)
+}
+
+// HandleE registers a new request handler returning an error with the given
+// path and method, and returns the registered route
+func (g *Group) HandleE(method, path string, handler HandlerFuncE) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.HandleE(method, g.prefix+path, handler)
+}
+
+// GETE is a shortcut for group.HandleE(http.MethodGet, path, handler)
+func (g *Group) GETE(path string, handler HandlerFuncE) {
+	g.HandleE(http.MethodGet, path, handler)
+}
+
+// HEADE is a shortcut for group.HandleE(http.MethodHead, path, handler)
+func (g *Group) HEADE(path string, handler HandlerFuncE) {
+	g.HandleE(http.MethodHead, path, handler)
+}
+
+// POSTE is a shortcut for group.HandleE(http.MethodPost, path, handler)
+func (g *Group) POSTE(path string, handler HandlerFuncE) {
+	g.HandleE(http.MethodPost, path, handler)
+}
+
+// PUTE is a shortcut for group.HandleE(http.MethodPut, path, handler)
+func (g *Group) PUTE(path string, handler HandlerFuncE) {
+	g.HandleE(http.MethodPut, path, handler)
+}
+
+// PATCHE is a shortcut for group.HandleE(http.MethodPatch, path, handler)
+func (g *Group) PATCHE(path string, handler HandlerFuncE) {
+	g.HandleE(http.MethodPatch, path, handler)
+}
+
+// DELETEE is a shortcut for group.HandleE(http.MethodDelete, path, handler)
+func (g *Group) DELETEE(path string, handler HandlerFuncE) {
+	g.HandleE(http.MethodDelete, path, handler)
+}
+
+// ANYE is a shortcut for group.HandleE(router.MethodWild, path, handler)
+func (g *Group) ANYE(path string, handler HandlerFuncE) {
+	g.HandleE(MethodWild, path, handler)
+}
+
+// errorFunc adapts the handler returning an error of the route to a
+// http.HandlerFunc
+func (router *Router) errorFunc(route *Route, handler HandlerFuncE) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := handler(w, r); err != nil {
+			router.handleError(w, r, route.errorHandler, err)
+		}
+	}
+}
+
+// HandleError replies to the request with the error handler of the router
+// or of the group of the route serving the request.
+// A custom PanicHandler can use it to render the panics like the errors.
+func (router *Router) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	router.handleError(w, r, router.matchedErrorHandler(r), err)
+}
+
+// matchedErrorHandler returns the error handler of the group of the route
+// serving the request, if any
+func (router *Router) matchedErrorHandler(r *http.Request) ErrorHandler {
+	methodIndex := router.methodIndexOf(r.Method)
+	if methodIndex == -1 {
+		methodIndex = wildIndex
+	}
+
+	e, _ := router.findEntry(methodIndex, router.routingPath(r))
+	if e == nil {
+		return nil
+	}
+
+	for _, route := range e.endpoint.routes {
+		if route.errorHandler != nil {
+			return route.errorHandler
+		}
+	}
+
+	return nil
+}
+
+// handleError replies to the request with the given error handler of a
+// group, or with the error handler of the router
+func (router *Router) handleError(w http.ResponseWriter, r *http.Request, h ErrorHandler, err error) {
+	if h == nil {
+		h = router.ErrorHandler
+	}
+
+	if h != nil {
+		h(w, r, err)
+		return
+	}
+
+	router.writeError(w, r, err)
+}
+
+// writeError is the default error handler.
+// The status code and message are the ones of the HTTPError wrapped by the
+// error, or 500 Internal Server Error, whose message isn't disclosed.
+// ValidationErrors are replied with the list of violations.
+func (router *Router) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		router.writeValidationErrors(w, r, validationErrs)
+		return
+	}
+
+	status, detail := http.StatusInternalServerError, ""
+
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.StatusCode()
+
+		if _, ok := httpErr.(*PanicError); !ok {
+			detail = httpErr.Error()
+		}
+	}
+
+	if router.Problems != nil {
+		router.writeProblem(w, r, status, detail, nil)
+		return
+	}
+
+	if detail == "" {
+		detail = http.StatusText(status)
+	}
+
+	http.Error(w, detail, status)
+}
+
+// recoverError handles the panics of the handlers as errors when the
+// PanicHandler is not set
+func (router *Router) recoverError(w http.ResponseWriter, r *http.Request) {
+	rcv := recover()
+	if rcv == nil {
+		return
+	}
+
+	if rcv == http.ErrAbortHandler {
+		panic(rcv)
+	}
+
+	router.HandleError(w, r, &PanicError{Value: rcv})
+}
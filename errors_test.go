@@ -0,0 +1,192 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterHandleE(t *testing.T) {
+	errNotFound := NewHTTPError(http.StatusNotFound, "user not found")
+
+	r := New()
+	r.GETE("/users/{id}", func(w http.ResponseWriter, req *http.Request) error {
+		if UserValue(req, "id") != "1" {
+			return fmt.Errorf("load user: %w", errNotFound)
+		}
+
+		w.Write([]byte("gopher"))
+
+		return nil
+	})
+	r.POSTE("/users", func(w http.ResponseWriter, req *http.Request) error {
+		return errors.New("database is down")
+	})
+	r.GET("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/users/1", http.StatusOK, "gopher"},
+		{http.MethodGet, "/users/2", http.StatusNotFound, "user not found\n"},
+		{http.MethodPost, "/users", http.StatusInternalServerError, "Internal Server Error\n"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("%s %s: code == %d, body == %q, want %d and %q", test.method, test.path, w.Code, w.Body.String(), test.code, test.body)
+		}
+	}
+
+	var handled []error
+
+	r.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		handled = append(handled, err)
+
+		var httpErr HTTPError
+		if errors.As(err, &httpErr) {
+			w.WriteHeader(httpErr.StatusCode())
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	api := r.Group("/api").ErrorHandler(func(w http.ResponseWriter, req *http.Request, err error) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	api.GETE("/fail", func(w http.ResponseWriter, req *http.Request) error {
+		return errors.New("fail")
+	})
+	api.GET("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	// The group of the route is resolved at its registration
+	orgs := r.Group("/orgs/{org}").ErrorHandler(func(w http.ResponseWriter, req *http.Request, err error) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	})
+	route := orgs.HandleE(http.MethodGet, "/fail", func(w http.ResponseWriter, req *http.Request) error {
+		return errors.New("fail")
+	})
+	orgs.GET("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	if route == nil || route.Path() != "/orgs/{org}/fail" {
+		t.Errorf("HandleE returned %v", route)
+	}
+
+	tests = []struct {
+		method string
+		path   string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/users/2", http.StatusNotFound, ""},
+		{http.MethodPost, "/users", http.StatusServiceUnavailable, ""},
+		{http.MethodGet, "/panic", http.StatusInternalServerError, ""},
+		{http.MethodGet, "/api/fail", http.StatusTeapot, ""},
+		{http.MethodGet, "/api/panic", http.StatusTeapot, ""},
+		{http.MethodGet, "/orgs/acme/fail", http.StatusPaymentRequired, ""},
+		{http.MethodGet, "/orgs/acme/panic", http.StatusPaymentRequired, ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("%s %s: code == %d, body == %q, want %d and %q", test.method, test.path, w.Code, w.Body.String(), test.code, test.body)
+		}
+	}
+
+	if len(handled) != 3 {
+		t.Fatalf("handled %d errors, want 3", len(handled))
+	}
+
+	if !errors.Is(handled[0], errNotFound) {
+		t.Errorf("handled %v, want %v", handled[0], errNotFound)
+	}
+
+	if err, ok := handled[2].(*PanicError); !ok || err.Value != "boom" {
+		t.Errorf("handled %v, want a panic error", handled[2])
+	}
+}
+
+func TestRouterHandleEProblems(t *testing.T) {
+	r := New()
+	r.Problems = &ProblemResponder{}
+	r.PUTE("/users/{id}", func(w http.ResponseWriter, req *http.Request) error {
+		return NewHTTPError(http.StatusConflict, "")
+	})
+	r.GET("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("secret")
+	})
+	r.PanicHandler = func(w http.ResponseWriter, req *http.Request, rcv interface{}) {
+		r.HandleError(w, req, &PanicError{Value: rcv})
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPut, "/users/1", `{"type":"about:blank","title":"Conflict","status":409,"detail":"Conflict","instance":"/users/1"}` + "\n"},
+		{http.MethodGet, "/panic", `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/panic"}` + "\n"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		if w.Body.String() != test.body {
+			t.Errorf("%s %s: body == %q, want %q", test.method, test.path, w.Body.String(), test.body)
+		}
+	}
+}
+
+func TestGroupErrorHandlerScope(t *testing.T) {
+	fail := func(w http.ResponseWriter, req *http.Request) error {
+		return errors.New("fail")
+	}
+
+	r := New()
+	r.Group("/api").ErrorHandler(func(w http.ResponseWriter, req *http.Request, err error) {
+		w.WriteHeader(http.StatusTeapot)
+	}).GETE("/users", fail)
+	r.Group("/api").GETE("/items", fail)
+	r.GETE("/api/orders", fail)
+	r.GET("/api/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/api/users", http.StatusTeapot},
+		{"/api/items", http.StatusInternalServerError},
+		{"/api/orders", http.StatusInternalServerError},
+		{"/api/panic", http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if w.Code != test.code {
+			t.Errorf("GET %s: status == %d, want %d", test.path, w.Code, test.code)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pedia/router/radix"
+)
+
+// DotDotPolicy defines how '..' segments in the values of catch-all params
+// are handled
+type DotDotPolicy int
+
+const (
+	// DotDotAllow keeps the '..' segments of the catch-all values
+	DotDotAllow DotDotPolicy = iota
+
+	// DotDotClean resolves the '..' segments of the catch-all values,
+	// without going above the catch-all, e.g. "a/../../b" becomes "b"
+	DotDotClean
+
+	// DotDotReject answers the requests with '..' segments in the catch-all
+	// values with 400 Bad Request
+	DotDotReject
+)
+
+// routingPath returns the path of the request which is matched against the
+// routes
+func (router *Router) routingPath(r *http.Request) string {
+	if router.UseEscapedPath {
+		return r.URL.EscapedPath()
+	}
+
+	return r.URL.Path
+}
+
+// escapePattern escapes the static parts of the pattern like the paths of
+// the requests are escaped, so they match the escaped paths
+func escapePattern(pattern string) string {
+	b := new(strings.Builder)
+
+	start, depth := 0, 0
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			if depth == 0 {
+				b.WriteString((&url.URL{Path: pattern[start:i]}).EscapedPath())
+				start = i
+			}
+
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				b.WriteString(pattern[start : i+1])
+				start = i + 1
+			}
+		}
+	}
+
+	b.WriteString((&url.URL{Path: pattern[start:]}).EscapedPath())
+
+	return b.String()
+}
+
+// locationPath returns the path to use in the Location header of the
+// redirects
+func (router *Router) locationPath(p string) string {
+	if router.UseEscapedPath {
+		return p
+	}
+
+	return (&url.URL{Path: p}).EscapedPath()
+}
+
+// decodeParams unescapes the param values matched on the escaped path and
+// applies the DotDotPolicy to the catch-all values
+func (router *Router) decodeParams(params []*patternParam, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		values := radix.UserValues(r)
+
+		for _, param := range params {
+			value, ok := values[param.name]
+			if !ok {
+				continue
+			}
+
+			if router.UseEscapedPath {
+				if unescaped, err := url.PathUnescape(value); err == nil {
+					value = unescaped
+				}
+			}
+
+			if param.catchAll && hasDotDot(value) {
+				switch router.CatchAllDotDot {
+				case DotDotReject:
+					router.writeProblem(w, r, http.StatusBadRequest, "invalid path", nil)
+					return
+				case DotDotClean:
+					value = cleanCatchAll(value)
+				}
+			}
+
+			r = setParam(r, param.name, value)
+		}
+
+		handler(w, r)
+	}
+}
+
+// hasDotDot reports whether the value has a '..' segment
+func hasDotDot(value string) bool {
+	for _, segment := range strings.Split(value, "/") {
+		if segment == ".." {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cleanCatchAll resolves the '.' and '..' segments of the catch-all value,
+// which can't go above its root
+func cleanCatchAll(value string) string {
+	cleaned := path.Clean("/" + value)[1:]
+
+	if strings.HasSuffix(value, "/") && cleaned != "" {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
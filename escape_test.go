@@ -0,0 +1,119 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterUseEscapedPath(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(UserValue(r, "name") + "|" + UserValue(r, "filepath")))
+	}
+
+	r := New()
+	r.UseEscapedPath = true
+	r.GET("/files/{name}", handler)
+	r.GET("/files/{name}/raw", handler)
+	r.GET("/static/{filepath:*}", handler)
+	r.GET("/café/{name}", handler)
+	r.GET("/a b/{name:[a-z]+}.txt", handler)
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/files/a%2Fb", http.StatusOK, "a/b|"},
+		{"/files/a%2Fb/raw", http.StatusOK, "a/b|"},
+		{"/files/hello%20world", http.StatusOK, "hello world|"},
+		{"/files/a/b", http.StatusNotFound, ""},
+		{"/static/css/a%2Fb.css", http.StatusOK, "|css/a/b.css"},
+		{"/static/css/../../etc/passwd", http.StatusOK, "|css/../../etc/passwd"},
+		{"/caf%C3%A9/1", http.StatusOK, "1|"},
+		{"/a%20b/x.txt", http.StatusOK, "x|"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if w.Code != test.code || (test.code == http.StatusOK && w.Body.String() != test.body) {
+			t.Errorf("%s: response == %d %q, want %d %q", test.path, w.Code, w.Body.String(), test.code, test.body)
+		}
+	}
+}
+
+func TestRouterCatchAllDotDot(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(UserValue(r, "filepath")))
+	}
+
+	tests := []struct {
+		policy  DotDotPolicy
+		escaped bool
+		path    string
+		code    int
+		body    string
+	}{
+		{DotDotClean, false, "/static/css/../../etc/passwd", http.StatusOK, "etc/passwd"},
+		{DotDotClean, false, "/static/css/../js/", http.StatusOK, "js/"},
+		{DotDotClean, true, "/static/css/..%2F..%2Fetc", http.StatusOK, "etc"},
+		{DotDotClean, false, "/static/a..b", http.StatusOK, "a..b"},
+		{DotDotReject, false, "/static/css/../x", http.StatusBadRequest, ""},
+		{DotDotReject, true, "/static/%2E%2E/x", http.StatusBadRequest, ""},
+		{DotDotReject, false, "/static/css/x", http.StatusOK, "css/x"},
+	}
+
+	for _, test := range tests {
+		r := New()
+		r.UseEscapedPath = test.escaped
+		r.CatchAllDotDot = test.policy
+		r.GET("/static/{filepath:*}", handler)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = test.path
+		req.URL.RawPath = ""
+
+		if test.escaped {
+			req = httptest.NewRequest(http.MethodGet, test.path, nil)
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("%v %s: response == %d %q, want %d %q", test.policy, test.path, w.Code, w.Body.String(), test.code, test.body)
+		}
+	}
+}
+
+func TestRouterRedirectFixedPath(t *testing.T) {
+	r := New()
+	r.GET("/users/{name}", func(w http.ResponseWriter, r *http.Request) {})
+	r.POST("/Hello World", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		method   string
+		path     string
+		location string
+	}{
+		{http.MethodGet, "/USERS/Gopher?tab=1", "/users/Gopher?tab=1"},
+		{http.MethodGet, "/a/../users//Gopher", "/users/Gopher"},
+		{http.MethodPost, "/hello%20world", "/Hello%20World"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		wantCode := http.StatusMovedPermanently
+		if test.method != http.MethodGet {
+			wantCode = http.StatusPermanentRedirect
+		}
+
+		if w.Code != wantCode || w.Header().Get("Location") != test.location {
+			t.Errorf("%s %s: response == %d %q, want %d %q", test.method, test.path, w.Code, w.Header().Get("Location"), wantCode, test.location)
+		}
+	}
+}
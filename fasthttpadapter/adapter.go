@@ -0,0 +1,122 @@
+// Package fasthttpadapter serves a router, or any http.Handler, from a
+// fasthttp server
+package fasthttpadapter
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pedia/router/radix"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+type requestCtxKey struct{}
+
+// Handler returns a fasthttp request handler serving the requests with the
+// given handler, usually a *router.Router.
+// The requests are converted without copying their headers and body, so
+// they must not be used after the handler returns. The params of the
+// matched route are set as user values of the fasthttp.RequestCtx once the
+// handler returns, so fasthttp middlewares can read them with
+// ctx.UserValue.
+// The responses are buffered and sent once the handler returns, so the
+// response writer isn't a http.Flusher and streaming handlers like SSE
+// aren't supported. The context of the requests isn't canceled when the
+// client disconnects, only when the server shuts down.
+func Handler(h http.Handler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		var r http.Request
+
+		if err := fasthttpadaptor.ConvertRequest(ctx, &r, true); err != nil {
+			ctx.Error("Bad Request", fasthttp.StatusBadRequest)
+			return
+		}
+
+		values := make(map[string]string)
+		req := radix.WithRequestValues(r.WithContext(context.WithValue(ctx, requestCtxKey{}, ctx)), values)
+
+		w := &responseWriter{ctx: ctx}
+		h.ServeHTTP(w, req)
+		w.finish()
+
+		for k, v := range values {
+			ctx.SetUserValue(k, v)
+		}
+	}
+}
+
+// RequestCtx returns the fasthttp request context of a request served by
+// Handler, or nil
+func RequestCtx(r *http.Request) *fasthttp.RequestCtx {
+	ctx, _ := r.Context().Value(requestCtxKey{}).(*fasthttp.RequestCtx)
+	return ctx
+}
+
+// responseWriter writes the response to the fasthttp response.
+// The headers are copied when the status code is written, like net/http
+// ignores the headers set after.
+type responseWriter struct {
+	ctx            *fasthttp.RequestCtx
+	header         http.Header
+	wroteHeader    bool
+	hasContentType bool
+}
+
+func (w *responseWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+	w.ctx.SetStatusCode(statusCode)
+
+	for k, vv := range w.header {
+		switch k {
+		case fasthttp.HeaderContentType:
+			// A nil value disables the sniffing, like net/http
+			w.hasContentType = true
+			if len(vv) == 0 {
+				w.ctx.Response.Header.SetNoDefaultContentType(true)
+				w.ctx.Response.Header.SetContentType("")
+				continue
+			}
+
+			w.ctx.Response.Header.SetContentType(vv[0])
+		case fasthttp.HeaderContentLength:
+			// Set by fasthttp from the body
+		default:
+			for _, v := range vv {
+				w.ctx.Response.Header.Add(k, v)
+			}
+		}
+	}
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+
+	return w.ctx.Write(p)
+}
+
+// finish writes the status code if the handler didn't, and sniffs the
+// content type of the body if the handler didn't set it
+func (w *responseWriter) finish() {
+	w.WriteHeader(http.StatusOK)
+
+	if body := w.ctx.Response.Body(); !w.hasContentType && len(body) > 0 {
+		if len(body) > 512 {
+			body = body[:512]
+		}
+
+		w.ctx.Response.Header.SetContentType(http.DetectContentType(body))
+	}
+}
@@ -0,0 +1,145 @@
+package fasthttpadapter
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestHandler(t *testing.T) {
+	r := router.New()
+	r.GET("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if RequestCtx(r) == nil {
+			t.Error("the request context isn't available")
+		}
+
+		w.Header().Set("X-User", router.UserValue(r, "id"))
+		w.Write([]byte("user " + router.UserValue(r, "id")))
+	})
+	r.POST("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+		w.Header().Set("X-Ignored", "true")
+	})
+	r.GET("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<!DOCTYPE html><p>hello</p>"))
+	})
+	r.GET("/files/", func(w http.ResponseWriter, r *http.Request) {})
+	r.SSE("/events", router.NewSSEBroker(1))
+
+	var userValue interface{}
+
+	handler := Handler(r)
+	server := &fasthttp.Server{Handler: func(ctx *fasthttp.RequestCtx) {
+		handler(ctx)
+		userValue = ctx.UserValue("id")
+	}}
+
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+
+	go server.Serve(ln) //nolint:errcheck
+
+	client := &fasthttp.Client{Dial: func(addr string) (net.Conn, error) {
+		return ln.Dial()
+	}}
+
+	tests := []struct {
+		method      string
+		path        string
+		body        string
+		code        int
+		respBody    string
+		contentType string
+		header      string
+		value       string
+	}{
+		{http.MethodGet, "/users/7", "", http.StatusOK, "user 7", "text/plain; charset=utf-8", "X-User: 7", "7"},
+		{http.MethodPost, "/echo", `{"a":1}`, http.StatusCreated, `{"a":1}`, "application/json", "X-Ignored: ", ""},
+		{http.MethodGet, "/html", "", http.StatusOK, "<!DOCTYPE html><p>hello</p>", "text/html; charset=utf-8", "", ""},
+		{http.MethodGet, "/events", "", http.StatusInternalServerError, "", "", "", ""},
+		{http.MethodGet, "/files", "", http.StatusMovedPermanently, "", "", "Location: /files/", ""},
+		{http.MethodDelete, "/users/7", "", http.StatusMethodNotAllowed, "", "", "Allow: GET, OPTIONS", ""},
+		{http.MethodGet, "/missing", "", http.StatusNotFound, "", "", "", ""},
+	}
+
+	for _, test := range tests {
+		req, resp := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+		req.SetRequestURI("http://example.com" + test.path)
+		req.Header.SetMethod(test.method)
+		req.SetBodyString(test.body)
+
+		userValue = nil
+
+		if err := client.Do(req, resp); err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode() != test.code {
+			t.Errorf("%s %s: status %d, want %d", test.method, test.path, resp.StatusCode(), test.code)
+		}
+
+		if test.respBody != "" && string(resp.Body()) != test.respBody {
+			t.Errorf("%s %s: body %q, want %q", test.method, test.path, resp.Body(), test.respBody)
+		}
+
+		if test.contentType != "" && string(resp.Header.ContentType()) != test.contentType {
+			t.Errorf("%s %s: content type %q, want %q", test.method, test.path, resp.Header.ContentType(), test.contentType)
+		}
+
+		if test.header != "" {
+			parts := strings.SplitN(test.header, ": ", 2)
+			name, value := parts[0], parts[1]
+			if got := string(resp.Header.Peek(name)); got != value {
+				t.Errorf("%s %s: header %s %q, want %q", test.method, test.path, name, got, value)
+			}
+		}
+
+		if got, _ := userValue.(string); got != test.value {
+			t.Errorf("%s %s: user value %q, want %q", test.method, test.path, got, test.value)
+		}
+
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+	}
+}
+
+func TestHandlerNilContentType(t *testing.T) {
+	r := router.New()
+	r.GET("/raw", func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Content-Type"] = nil
+		w.Write([]byte("<!DOCTYPE html>"))
+	})
+
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+
+	go fasthttp.Serve(ln, Handler(r)) //nolint:errcheck
+
+	// The client adds the default content type, read the raw response
+	conn, err := ln.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conn.Write([]byte("GET /raw HTTP/1.1\r\nHost: example.com\r\nConnection: close\r\n\r\n")) //nolint:errcheck
+
+	resp, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(resp), "Content-Type") || !strings.HasSuffix(string(resp), "<!DOCTYPE html>") {
+		t.Errorf("response %q, want no content type", resp)
+	}
+}
@@ -13,13 +13,53 @@ func (g *Group) Group(path string) *Group {
 		return g
 	}
 
-	return g.router.Group(g.prefix + path)
+	sub := g.router.Group(g.prefix + path)
+	sub.parent = g
+
+	return sub
+}
+
+// registering makes the router resolve the settings of the group into the
+// routes registered until the returned function is called
+func (g *Group) registering() func() {
+	prev := g.router.group
+	g.router.group = g
+
+	return func() { g.router.group = prev }
+}
+
+// resolve sets the settings of the group, or of its closest parent setting
+// them, on the route registered through the group
+func (g *Group) resolve(route *Route) {
+	for ; g != nil; g = g.parent {
+		if route.caseInsensitive == nil {
+			route.caseInsensitive = g.caseInsensitive
+		}
+
+		if route.redirect == nil {
+			route.redirect = g.redirect
+		}
+
+		if route.errorHandler == nil {
+			route.errorHandler = g.errorHandler
+		}
+
+		if route.consumes == nil {
+			route.consumes = g.consumes
+		}
+
+		if route.produces == nil {
+			route.produces = g.produces
+		}
+	}
 }
 
 // GET is a shortcut for group.Handle(http.MethodGet, path, handler)
 func (g *Group) GET(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.GET(g.prefix+path, handler)
 }
 
@@ -27,6 +67,8 @@ func (g *Group) GET(path string, handler http.HandlerFunc) {
 func (g *Group) HEAD(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.HEAD(g.prefix+path, handler)
 }
 
@@ -34,6 +76,8 @@ func (g *Group) HEAD(path string, handler http.HandlerFunc) {
 func (g *Group) POST(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.POST(g.prefix+path, handler)
 }
 
@@ -41,6 +85,8 @@ func (g *Group) POST(path string, handler http.HandlerFunc) {
 func (g *Group) PUT(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.PUT(g.prefix+path, handler)
 }
 
@@ -48,6 +94,8 @@ func (g *Group) PUT(path string, handler http.HandlerFunc) {
 func (g *Group) PATCH(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.PATCH(g.prefix+path, handler)
 }
 
@@ -55,6 +103,8 @@ func (g *Group) PATCH(path string, handler http.HandlerFunc) {
 func (g *Group) DELETE(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.DELETE(g.prefix+path, handler)
 }
 
@@ -62,6 +112,8 @@ func (g *Group) DELETE(path string, handler http.HandlerFunc) {
 func (g *Group) CONNECT(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.CONNECT(g.prefix+path, handler)
 }
 
@@ -69,6 +121,8 @@ func (g *Group) CONNECT(path string, handler http.HandlerFunc) {
 func (g *Group) OPTIONS(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.OPTIONS(g.prefix+path, handler)
 }
 
@@ -76,6 +130,8 @@ func (g *Group) OPTIONS(path string, handler http.HandlerFunc) {
 func (g *Group) TRACE(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.TRACE(g.prefix+path, handler)
 }
 
@@ -85,6 +141,8 @@ func (g *Group) TRACE(path string, handler http.HandlerFunc) {
 func (g *Group) ANY(path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.ANY(g.prefix+path, handler)
 }
 
@@ -100,6 +158,8 @@ func (g *Group) ANY(path string, handler http.HandlerFunc) {
 func (g *Group) ServeFiles(path string, rootPath string) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.ServeFiles(g.prefix+path, rootPath)
 }
 
@@ -116,6 +176,8 @@ func (g *Group) ServeFiles(path string, rootPath string) {
 func (g *Group) ServeFilesCustom(path string, fs http.FileSystem) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.ServeFilesCustom(g.prefix+path, fs)
 }
 
@@ -130,5 +192,17 @@ func (g *Group) ServeFilesCustom(path string, fs http.FileSystem) {
 func (g *Group) Handle(method, path string, handler http.HandlerFunc) {
 	validatePath(path)
 
+	defer g.registering()()
+
 	g.router.Handle(method, g.prefix+path, handler)
 }
+
+// HandleRoute registers a new request handler like Handle does and returns
+// the registered route, so optional metadata can be attached to it.
+func (g *Group) HandleRoute(method, path string, handler http.HandlerFunc) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.HandleRoute(method, g.prefix+path, handler)
+}
@@ -0,0 +1,222 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// InputRule declares the rules checked on a query param or a header of the
+// requests before the handler of a route runs
+type InputRule struct {
+	// In is the location of the input: query or header
+	In          string
+	Name        string
+	Description string
+	Required    bool
+	// Min and Max bound the numeric value of the input
+	Min, Max *float64
+	// Enum lists the allowed values of the input
+	Enum    []string
+	Pattern *regexp.Regexp
+	// MaxLength is the maximum number of characters of the input, if not 0
+	MaxLength int
+}
+
+// Rule configures an InputRule
+type Rule func(*InputRule)
+
+// Required requires the input to be present
+func Required() Rule {
+	return func(input *InputRule) {
+		input.Required = true
+	}
+}
+
+// Min requires the input to be a number greater than or equal to min
+func Min(min float64) Rule {
+	return func(input *InputRule) {
+		input.Min = &min
+	}
+}
+
+// Max requires the input to be a number less than or equal to max
+func Max(max float64) Rule {
+	return func(input *InputRule) {
+		input.Max = &max
+	}
+}
+
+// Enum requires the input to be one of the given values
+func Enum(values ...string) Rule {
+	return func(input *InputRule) {
+		input.Enum = values
+	}
+}
+
+// Pattern requires the input to match the regular expression.
+// It panics if the expression is invalid.
+func Pattern(expr string) Rule {
+	re := regexp.MustCompile(expr)
+
+	return func(input *InputRule) {
+		input.Pattern = re
+	}
+}
+
+// MaxLength requires the input to have at most n characters
+func MaxLength(n int) Rule {
+	return func(input *InputRule) {
+		input.MaxLength = n
+	}
+}
+
+// Describe sets the description of the input in the documentation
+func Describe(description string) Rule {
+	return func(input *InputRule) {
+		input.Description = description
+	}
+}
+
+// Query declares the rules of the query param with the given name.
+// The requests violating them are answered with 400 Bad Request and the
+// list of violations.
+func (route *Route) Query(name string, rules ...Rule) *Route {
+	return route.input("query", name, rules)
+}
+
+// Header declares the rules of the header with the given name.
+// The requests violating them are answered with 400 Bad Request and the
+// list of violations.
+func (route *Route) Header(name string, rules ...Rule) *Route {
+	return route.input("header", name, rules)
+}
+
+// Inputs returns the rules of the query params and headers of the route,
+// in declaration order
+func (route *Route) Inputs() []InputRule {
+	inputs := make([]InputRule, len(route.inputs))
+	for i, input := range route.inputs {
+		inputs[i] = *input
+	}
+
+	return inputs
+}
+
+func (route *Route) input(in, name string, rules []Rule) *Route {
+	if name == "" {
+		panic("input name must not be empty")
+	}
+
+	if in == "header" {
+		name = http.CanonicalHeaderKey(name)
+	}
+
+	input := &InputRule{In: in, Name: name}
+	for _, rule := range rules {
+		rule(input)
+	}
+
+	for i, existing := range route.inputs {
+		if existing.In == in && existing.Name == name {
+			route.inputs[i] = input
+			return route
+		}
+	}
+
+	if len(route.inputs) == 0 {
+		route.wrap(route.validateInputs)
+	}
+
+	route.inputs = append(route.inputs, input)
+
+	return route
+}
+
+// validateInputs returns the handler checking the inputs of the request
+// before calling the given handler
+func (route *Route) validateInputs(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var errs ValidationErrors
+
+		query := r.URL.Query()
+
+		for _, input := range route.inputs {
+			var values []string
+			if input.In == "query" {
+				values = query[input.Name]
+			} else {
+				values = r.Header.Values(input.Name)
+			}
+
+			if msg := input.check(values); msg != "" {
+				errs = append(errs, FieldError{In: input.In, Name: input.Name, Message: msg})
+			}
+		}
+
+		if len(errs) > 0 {
+			route.router.writeValidationErrors(w, r, errs)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// check returns the violation of the rules by the values of the input, if
+// any
+func (input *InputRule) check(values []string) string {
+	if len(values) == 0 {
+		if input.Required {
+			return "is required"
+		}
+
+		return ""
+	}
+
+	for _, value := range values {
+		if input.MaxLength > 0 && utf8.RuneCountInString(value) > input.MaxLength {
+			return fmt.Sprintf("must have at most %d characters", input.MaxLength)
+		}
+
+		if len(input.Enum) > 0 && !containsString(input.Enum, value) {
+			return "must be one of " + strings.Join(input.Enum, ", ")
+		}
+
+		if input.Pattern != nil && !input.Pattern.MatchString(value) {
+			return "must match '" + input.Pattern.String() + "'"
+		}
+
+		if input.Min == nil && input.Max == nil {
+			continue
+		}
+
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "must be a number"
+		}
+
+		if input.Min != nil && n < *input.Min {
+			return "must be greater than or equal to " + strconv.FormatFloat(*input.Min, 'g', -1, 64)
+		}
+
+		if input.Max != nil && n > *input.Max {
+			return "must be less than or equal to " + strconv.FormatFloat(*input.Max, 'g', -1, 64)
+		}
+	}
+
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
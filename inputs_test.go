@@ -0,0 +1,101 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pedia/router/openapi"
+)
+
+func TestRouteInputs(t *testing.T) {
+	r := New()
+	route := r.HandleRoute(http.MethodGet, "/search", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("ok"))
+	}).
+		Query("q", Required(), MaxLength(5), Describe("search terms")).
+		Query("page", Min(1), Max(10)).
+		Query("sort", Enum("asc", "desc")).
+		Header("x-request-id", Pattern("^[0-9a-f]+$"))
+
+	tests := []struct {
+		path   string
+		header string
+		code   int
+		body   string
+	}{
+		{"/search?q=go&page=2&sort=asc", "abc", http.StatusOK, "ok"},
+		{"/search?q=gophe", "", http.StatusOK, "ok"},
+		{"/search?page=0&sort=up", "xyz", http.StatusBadRequest, `{"errors":[` +
+			`{"in":"query","name":"q","message":"is required"},` +
+			`{"in":"query","name":"page","message":"must be greater than or equal to 1"},` +
+			`{"in":"query","name":"sort","message":"must be one of asc, desc"},` +
+			`{"in":"header","name":"X-Request-Id","message":"must match '^[0-9a-f]+$'"}]}` + "\n"},
+		{"/search?q=gophers&page=x", "", http.StatusBadRequest, `{"errors":[` +
+			`{"in":"query","name":"q","message":"must have at most 5 characters"},` +
+			`{"in":"query","name":"page","message":"must be a number"}]}` + "\n"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		if test.header != "" {
+			req.Header.Set("X-Request-Id", test.header)
+		}
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("GET %s: code == %d, body == %q, want %d and %q", test.path, w.Code, w.Body.String(), test.code, test.body)
+		}
+	}
+
+	inputs := route.Inputs()
+	if len(inputs) != 4 {
+		t.Fatalf("got %d inputs, want 4", len(inputs))
+	}
+
+	if q := inputs[0]; q.In != "query" || q.Name != "q" || !q.Required || q.MaxLength != 5 || q.Description != "search terms" {
+		t.Errorf("unexpected input %+v", q)
+	}
+
+	if h := inputs[3]; h.In != "header" || h.Name != "X-Request-Id" || h.Pattern.String() != "^[0-9a-f]+$" {
+		t.Errorf("unexpected input %+v", h)
+	}
+
+	route.Query("q", MaxLength(10))
+
+	if inputs := route.Inputs(); len(inputs) != 4 || inputs[0].Required || inputs[0].MaxLength != 10 {
+		t.Errorf("the input wasn't redeclared: %+v", inputs[0])
+	}
+}
+
+func TestRouteInputsOpenAPI(t *testing.T) {
+	r := New()
+	r.HandleRoute(http.MethodGet, "/users/{id}", func(w http.ResponseWriter, req *http.Request) {}).
+		Query("fields", Enum("name", "email"), Describe("returned fields")).
+		Query("limit", Required(), Min(1), Max(100))
+
+	op := r.OpenAPI(openapi.Info{Title: "test", Version: "1"}).Paths["/users/{id}"].Get
+
+	data, _ := json.Marshal(op.Parameters)
+
+	var got []map[string]interface{}
+	json.Unmarshal(data, &got)
+
+	want := []map[string]interface{}{
+		{"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}},
+		{"name": "fields", "in": "query", "description": "returned fields", "schema": map[string]interface{}{
+			"type": "string", "enum": []interface{}{"name", "email"},
+		}},
+		{"name": "limit", "in": "query", "required": true, "schema": map[string]interface{}{
+			"type": "number", "minimum": float64(1), "maximum": float64(100),
+		}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parameters == %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+// Package codegentest checks the code generated by Router.GenerateCode for
+// the routes of Router
+package codegentest
+
+import (
+	"net/http"
+
+	"github.com/pedia/router"
+)
+
+//go:generate go run gen.go
+
+// Options are the options of the generated code
+var Options = router.CodegenOptions{Package: "codegentest", Client: true}
+
+// Router returns the router whose routes are generated
+func Router() *router.Router {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + r.URL.EscapedPath()))
+	}
+
+	r := router.New()
+	r.UseEscapedPath = true
+	r.HandleRoute(http.MethodGet, "/", handler).Name("home")
+	r.HandleRoute(http.MethodGet, "/users/{id:[0-9]+}", handler).Name("user.show")
+	r.HandleRoute(http.MethodPut, "/users/{user_id:[0-9]+}/name", handler).Name("user.rename").Consumes("text/plain")
+	r.HandleRoute(http.MethodGet, "/tags/{tag:[a-z]+}", handler).Name("tag")
+	r.HandleRoute(http.MethodGet, "/files/{path:*}", handler).Name("files")
+	r.HandleRoute(http.MethodGet, "/search/{lang?:[a-z]{2}}/{query?}", handler).Name("search")
+	r.HandleRoute(http.MethodDelete, "/orgs/{type}/{name}", handler).Name("org.delete")
+	r.HandleRoute(http.MethodGet, "/unnamed", handler)
+
+	return r
+}
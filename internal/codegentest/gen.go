@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+// This program generates routes_gen.go
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/pedia/router/internal/codegentest"
+)
+
+func main() {
+	src, err := codegentest.Router().GenerateCode(codegentest.Options)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("routes_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,176 @@
+package codegentest
+
+import (
+	"context"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := Router().GenerateCode(Options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile("routes_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(golden) != string(src) {
+		t.Errorf("routes_gen.go is outdated, run go generate, got:\n%s", src)
+	}
+}
+
+func TestGenerateTypeCheck(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	// Params named like the imports, the helpers, the client variables and
+	// each other
+	collisions := router.New()
+	collisions.HandleRoute(http.MethodGet, "/a/{url}/{fmt}/{strconv:[0-9]+}/{strings}/{http}/{io}/{context}", handler).Name("imports")
+	collisions.HandleRoute(http.MethodGet, "/b/{user_id:[a-z]+}/{userId:[a-z]+}/{user-id}", handler).Name("dups")
+	collisions.HandleRoute(http.MethodPost, "/c/{path}/{path_param}/{ctx}/{body}/{c}/{err}", handler).Name("locals")
+	collisions.HandleRoute(http.MethodGet, "/d/{escape_path}/{files:*}", handler).Name("helpers")
+	collisions.HandleRoute(http.MethodGet, "/e/{re_regex_x}/{x:[a-z]+}", handler).Name("regex")
+
+	tests := []struct {
+		name   string
+		router *router.Router
+	}{
+		{"routes", Router()},
+		{"collisions", collisions},
+	}
+
+	for _, test := range tests {
+		src, err := test.router.GenerateCode(Options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fset := token.NewFileSet()
+
+		file, err := parser.ParseFile(fset, "routes_gen.go", src, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		conf := types.Config{Importer: importer.Default()}
+		if _, err := conf.Check("codegentest", fset, []*ast.File{file}, nil); err != nil {
+			t.Errorf("%s: %v, in:\n%s", test.name, err, src)
+		}
+	}
+}
+
+func TestURLBuilders(t *testing.T) {
+	r := Router()
+
+	mustURL := func(path string, err error) string {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	tests := []struct {
+		path string
+		name string
+	}{
+		{URLHome(), "home"},
+		{URLUserShow(42), "user.show"},
+		{URLUserRename(7), "user.rename"},
+		{mustURL(URLTag("go")), "tag"},
+		{URLFiles("a dir/b.txt"), "files"},
+		{mustURL(URLSearch("", "")), "search"},
+		{mustURL(URLSearch("en", "")), "search"},
+		{mustURL(URLSearch("en", "gopher tips")), "search"},
+		{URLOrgDelete("team", "a/b"), "org.delete"},
+	}
+
+	want := []string{
+		"/",
+		"/users/42",
+		"/users/7/name",
+		"/tags/go",
+		"/files/a%20dir/b.txt",
+		"/search",
+		"/search/en",
+		"/search/en/gopher%20tips",
+		"/orgs/team/a%2Fb",
+	}
+
+	for i, test := range tests {
+		if test.path != want[i] {
+			t.Errorf("built %q, want %q", test.path, want[i])
+		}
+
+		method := http.MethodGet
+		if route := r.NamedRoute(test.name); route != nil {
+			method = route.Method()
+		}
+
+		req := httptest.NewRequest(method, "http://example.com"+test.path, nil)
+		if route, _ := r.Match(req); route == nil || route.GetName() != test.name {
+			t.Errorf("%s %s doesn't match the route %s", method, test.path, test.name)
+		}
+	}
+
+	if _, err := URLTag("Go"); err == nil {
+		t.Error("URLTag(\"Go\") didn't fail")
+	}
+
+	if _, err := URLSearch("english", ""); err == nil {
+		t.Error("URLSearch(\"english\") didn't fail")
+	}
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(Router())
+	defer server.Close()
+
+	client := NewClient(server.URL + "/")
+	ctx := context.Background()
+
+	call := func(resp *http.Response, err error) string {
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+
+		return string(body)
+	}
+
+	tests := []struct {
+		got, want string
+	}{
+		{call(client.Home(ctx)), "GET /"},
+		{call(client.UserShow(ctx, 42)), "GET /users/42"},
+		{call(client.UserRename(ctx, 7, strings.NewReader("gopher"))), "PUT /users/7/name"},
+		{call(client.Files(ctx, "a/b.txt")), "GET /files/a/b.txt"},
+		{call(client.OrgDelete(ctx, "team", "gophers")), "DELETE /orgs/team/gophers"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %q, want %q", test.got, test.want)
+		}
+	}
+
+	if _, err := client.Tag(ctx, "Go"); err == nil {
+		t.Error("client.Tag(\"Go\") didn't fail")
+	}
+}
@@ -0,0 +1,79 @@
+// Package matchertest checks that the static matchers generated by
+// Router.GenerateMatcher agree with the radix trees
+package matchertest
+
+import (
+	"net/http"
+
+	"github.com/pedia/router"
+	"github.com/pedia/router/radix"
+)
+
+//go:generate go run gen.go
+
+// Options are the options of the generated matcher
+var Options = radix.GenerateOptions{Package: "matchertest"}
+
+// Paths are the paths of the routes, in registration order
+var Paths = []string{
+	"/",
+	"/plaintext",
+	"/json",
+	"/fortune",
+	"/fortune-quick",
+	"/users",
+	"/users/admin",
+	"/users/{name}",
+	"/users/{name}/jobs",
+	"/data/",
+	"/data/orders",
+	"/static/{filepath:*}",
+	"/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/files",
+	"/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/data",
+	"/api/prefix/files",
+	"/prefix{name:[a-z]+}suffix/data",
+	"/prefix{name:[a-z]+}/data",
+	"/api/{file}.json",
+	"/items/{id:[0-9]+}",
+	"/items/new-{slug:[a-z-]+}",
+	"/items/{id:[0-9]+}/edit",
+	"/hello/{a}/{b}/{c}",
+	"/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/",
+	"/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/{slug}",
+	"/Docs/Index",
+	"/Docs/{page}/",
+}
+
+// Tree returns a radix tree of the paths, whose values are the paths
+func Tree() *radix.Tree {
+	tree := radix.New()
+
+	for _, path := range Paths {
+		tree.Insert(path, path)
+	}
+
+	return tree
+}
+
+// Router returns a router with a GET route per path, using the given
+// matcher if not nil
+func Router(m radix.Matcher) *router.Router {
+	r := router.New()
+	r.CaseInsensitive = true
+
+	if m != nil {
+		r.UseMatcher(m)
+	}
+
+	for _, path := range Paths {
+		path := path
+
+		r.GET(path, func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(path))
+		})
+	}
+
+	r.POST("/users/{name}", func(w http.ResponseWriter, req *http.Request) {})
+
+	return r
+}
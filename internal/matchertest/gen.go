@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+// This program generates matcher_gen.go
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/pedia/router/internal/matchertest"
+)
+
+func main() {
+	src, err := matchertest.Router(nil).GenerateMatcher(matchertest.Options)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("matcher_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,3123 @@
+// Code generated by radix.Tree.Generate. DO NOT EDIT.
+
+package matchertest
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pedia/router/radix"
+	"github.com/valyala/bytebufferpool"
+)
+
+var _MatcherRegexes = [...]*regexp.Regexp{
+	regexp.MustCompile("(.*).json"),
+	regexp.MustCompile("(V[0-9])_([a-z]+)_sufix"),
+	regexp.MustCompile("([a-z]+)suffix"),
+	regexp.MustCompile("([a-z]+)"),
+	regexp.MustCompile("([0-9]+)"),
+	regexp.MustCompile("([a-z-]+)"),
+	regexp.MustCompile("([0-9]{4})"),
+	regexp.MustCompile("([0-9]{2})"),
+}
+
+// Matcher is a static matcher generated from a radix tree
+type Matcher struct{}
+
+// Find returns the value registered with the given path, like radix.Tree.Find
+func (m Matcher) Find(path string) (interface{}, radix.Params, bool) {
+	return m.FindFunc(path, nil)
+}
+
+// FindFunc returns the value registered with the given path, like
+// radix.Tree.FindFunc
+func (m Matcher) FindFunc(path string, accept func(value interface{}) bool) (interface{}, radix.Params, bool) {
+	var params radix.Params
+
+	if len(path) > 1 {
+		if path[:1] != "/" {
+			return nil, nil, false
+		}
+
+		v, tsr := m.get0(path[1:], &params, accept)
+		if v == nil {
+			return nil, nil, tsr
+		}
+
+		for i, j := 0, len(params)-1; i < j; i, j = i+1, j-1 {
+			params[i], params[j] = params[j], params[i]
+		}
+
+		return v, params, false
+	} else if path == "/" {
+		if accept == nil || accept("/") {
+			return "/", nil, false
+		}
+	}
+
+	return nil, nil, false
+}
+
+// FindCaseInsensitivePath makes a case-insensitive lookup of the given
+// path, like radix.Tree.FindCaseInsensitivePath
+func (m Matcher) FindCaseInsensitivePath(path string, fixTrailingSlash bool, buf *bytebufferpool.ByteBuffer) bool {
+	found, tsr := m.find0(path, buf)
+
+	if !found || (tsr && !fixTrailingSlash) {
+		buf.Reset()
+
+		return false
+	}
+
+	return true
+}
+
+// paramEnd returns the end of the param at the beginning of the path,
+// and its values, or -1 if it doesn't match its regex
+func (m Matcher) paramEnd(path string, re *regexp.Regexp) (int, []string) {
+	end := strings.IndexByte(path, '/')
+	if end == -1 {
+		end = len(path)
+	}
+
+	if re == nil {
+		return end, []string{path[:end]}
+	}
+
+	index := re.FindStringSubmatchIndex(path[:end])
+	if len(index) == 0 || index[0] != 0 {
+		return -1, nil
+	}
+
+	values := make([]string, 0, len(index)/2-1)
+	for i := 2; i < len(index); i += 2 {
+		values = append(values, path[index[i]:index[i+1]])
+	}
+
+	return index[1], values
+}
+
+func (m Matcher) get0(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'f' {
+		if len(path) > 7 {
+			if path[:7] == "fortune" {
+				if v, tsr := m.get1(path[7:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "fortune" {
+			if accept == nil || accept("/fortune") {
+				return "/fortune", false
+			}
+		}
+	}
+
+	if path[0] == 'a' {
+		if len(path) > 4 {
+			if path[:4] == "api/" {
+				if v, tsr := m.get5(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "api/" {
+			return nil, false
+		}
+	}
+
+	if path[0] == 'p' {
+		if len(path) > 1 {
+			if v, tsr := m.get17(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "p" {
+			return nil, false
+		}
+	}
+
+	if path[0] == 'i' {
+		if len(path) > 6 {
+			if path[:6] == "items/" {
+				if v, tsr := m.get27(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "items/" {
+			return nil, false
+		}
+	}
+
+	if path[0] == 'D' {
+		if len(path) > 5 {
+			if path[:5] == "Docs/" {
+				if v, tsr := m.get35(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "Docs/" {
+			return nil, false
+		}
+	}
+
+	if path[0] == 'j' {
+		if len(path) > 4 {
+			if path[:4] == "json" {
+				if v, tsr := m.get40(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "json" {
+			if accept == nil || accept("/json") {
+				return "/json", false
+			}
+		}
+	}
+
+	if path[0] == 'u' {
+		if len(path) > 5 {
+			if path[:5] == "users" {
+				if v, tsr := m.get42(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "users" {
+			if accept == nil || accept("/users") {
+				return "/users", false
+			}
+		}
+	}
+
+	if path[0] == 'd' {
+		if len(path) > 4 {
+			if path[:4] == "data" {
+				if v, tsr := m.get50(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "data" {
+			return nil, true
+		}
+	}
+
+	if path[0] == 's' {
+		if len(path) > 6 {
+			if path[:6] == "static" {
+				if v, tsr := m.get54(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "static" {
+			return nil, true
+		}
+	}
+
+	if path[0] == 'h' {
+		if len(path) > 6 {
+			if path[:6] == "hello/" {
+				if v, tsr := m.get56(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "hello/" {
+			return nil, false
+		}
+	}
+
+	if path[0] == 'b' {
+		if len(path) > 5 {
+			if path[:5] == "blog/" {
+				if v, tsr := m.get63(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "blog/" {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find0(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild0(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		buf.WriteString("/")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild0(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find1(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find5(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find17(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find27(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find35(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find40(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find42(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find50(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find54(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find56(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find63(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get1(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '-' {
+		if len(path) > 6 {
+			if path[:6] == "-quick" {
+				if v, tsr := m.get2(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "-quick" {
+			if accept == nil || accept("/fortune-quick") {
+				return "/fortune-quick", false
+			}
+		}
+	}
+
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get4(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find1(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 7 {
+		if !strings.EqualFold(path[:7], "fortune") {
+			return false, false
+		}
+
+		buf.WriteString("fortune")
+
+		if found, tsr := m.findFromChild1(path[7:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-7]
+	} else if strings.EqualFold(path, "fortune") {
+		buf.WriteString("fortune")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild1(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find2(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find4(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get2(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get3(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find2(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "-quick") {
+			return false, false
+		}
+
+		buf.WriteString("-quick")
+
+		if found, tsr := m.findFromChild2(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "-quick") {
+		buf.WriteString("-quick")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild2(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find3(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get3(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find3(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild3(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild3(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get4(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find4(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild4(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild4(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get5(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'p' {
+		if len(path) > 6 {
+			if path[:6] == "prefix" {
+				if v, tsr := m.get6(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "prefix" {
+			return nil, false
+		}
+	}
+
+	if end, values := m.paramEnd(path, _MatcherRegexes[0]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get15(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "file", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			if accept == nil || accept("/api/{file}.json") {
+				*params = append(*params, radix.Param{Key: "file", Value: values[0]})
+
+				return "/api/{file}.json", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find5(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "api/") {
+			return false, false
+		}
+
+		buf.WriteString("api/")
+
+		if found, tsr := m.findFromChild5(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "api/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild5(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find6(path, buf); found {
+		return found, tsr
+	}
+
+	if end, _ := m.paramEnd(path, _MatcherRegexes[0]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild15(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			return true, false
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get6(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 6 {
+			if path[:6] == "/files" {
+				if v, tsr := m.get7(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "/files" {
+			if accept == nil || accept("/api/prefix/files") {
+				return "/api/prefix/files", false
+			}
+		}
+	}
+
+	if end, values := m.paramEnd(path, _MatcherRegexes[1]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get9(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "name", Value: values[1]})
+				*params = append(*params, radix.Param{Key: "version", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find6(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "prefix") {
+			return false, false
+		}
+
+		buf.WriteString("prefix")
+
+		if found, tsr := m.findFromChild6(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "prefix") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild6(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find7(path, buf); found {
+		return found, tsr
+	}
+
+	if end, _ := m.paramEnd(path, _MatcherRegexes[1]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild9(path[end:], buf); found {
+				return found, tsr
+			}
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get7(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get8(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find7(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "/files") {
+			return false, false
+		}
+
+		buf.WriteString("/files")
+
+		if found, tsr := m.findFromChild7(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "/files") {
+		buf.WriteString("/files")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild7(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find8(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get8(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find8(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild8(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild8(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get9(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get10(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild9(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find10(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get10(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'f' {
+		if len(path) > 5 {
+			if path[:5] == "files" {
+				if v, tsr := m.get11(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "files" {
+			if accept == nil || accept("/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/files") {
+				return "/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/files", false
+			}
+		}
+	}
+
+	if path[0] == 'd' {
+		if len(path) > 4 {
+			if path[:4] == "data" {
+				if v, tsr := m.get13(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "data" {
+			if accept == nil || accept("/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/data") {
+				return "/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/data", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find10(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild10(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild10(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find11(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find13(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get11(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get12(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find11(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "files") {
+			return false, false
+		}
+
+		buf.WriteString("files")
+
+		if found, tsr := m.findFromChild11(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "files") {
+		buf.WriteString("files")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild11(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find12(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get12(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find12(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild12(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild12(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get13(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get14(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find13(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "data") {
+			return false, false
+		}
+
+		buf.WriteString("data")
+
+		if found, tsr := m.findFromChild13(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "data") {
+		buf.WriteString("data")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild13(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find14(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get14(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find14(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild14(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild14(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get15(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get16(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild15(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find16(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get16(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find16(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild16(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild16(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get17(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'r' {
+		if len(path) > 5 {
+			if path[:5] == "refix" {
+				if v, tsr := m.get18(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "refix" {
+			return nil, false
+		}
+	}
+
+	if path[0] == 'l' {
+		if len(path) > 8 {
+			if path[:8] == "laintext" {
+				if v, tsr := m.get25(path[8:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "laintext" {
+			if accept == nil || accept("/plaintext") {
+				return "/plaintext", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find17(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "p") {
+			return false, false
+		}
+
+		buf.WriteString("p")
+
+		if found, tsr := m.findFromChild17(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "p") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild17(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find18(path, buf); found {
+		return found, tsr
+	}
+
+	if found, tsr := m.find25(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get18(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, _MatcherRegexes[2]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get19(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "name", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+		}
+	}
+
+	if end, values := m.paramEnd(path, _MatcherRegexes[3]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get22(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "name", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find18(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "refix") {
+			return false, false
+		}
+
+		buf.WriteString("refix")
+
+		if found, tsr := m.findFromChild18(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "refix") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild18(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, _MatcherRegexes[2]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild19(path[end:], buf); found {
+				return found, tsr
+			}
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	if end, _ := m.paramEnd(path, _MatcherRegexes[3]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild22(path[end:], buf); found {
+				return found, tsr
+			}
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get19(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 5 {
+			if path[:5] == "/data" {
+				if v, tsr := m.get20(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "/data" {
+			if accept == nil || accept("/prefix{name:[a-z]+}suffix/data") {
+				return "/prefix{name:[a-z]+}suffix/data", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild19(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find20(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get20(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get21(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find20(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "/data") {
+			return false, false
+		}
+
+		buf.WriteString("/data")
+
+		if found, tsr := m.findFromChild20(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "/data") {
+		buf.WriteString("/data")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild20(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find21(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get21(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find21(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild21(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild21(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get22(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 5 {
+			if path[:5] == "/data" {
+				if v, tsr := m.get23(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "/data" {
+			if accept == nil || accept("/prefix{name:[a-z]+}/data") {
+				return "/prefix{name:[a-z]+}/data", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild22(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find23(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get23(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get24(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find23(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "/data") {
+			return false, false
+		}
+
+		buf.WriteString("/data")
+
+		if found, tsr := m.findFromChild23(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "/data") {
+		buf.WriteString("/data")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild23(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find24(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get24(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find24(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild24(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild24(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get25(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get26(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find25(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 8 {
+		if !strings.EqualFold(path[:8], "laintext") {
+			return false, false
+		}
+
+		buf.WriteString("laintext")
+
+		if found, tsr := m.findFromChild25(path[8:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-8]
+	} else if strings.EqualFold(path, "laintext") {
+		buf.WriteString("laintext")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild25(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find26(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get26(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find26(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild26(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild26(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get27(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'n' {
+		if len(path) > 4 {
+			if path[:4] == "new-" {
+				if v, tsr := m.get28(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "new-" {
+			return nil, false
+		}
+	}
+
+	if end, values := m.paramEnd(path, _MatcherRegexes[4]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get31(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "id", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			if accept == nil || accept("/items/{id:[0-9]+}") {
+				*params = append(*params, radix.Param{Key: "id", Value: values[0]})
+
+				return "/items/{id:[0-9]+}", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find27(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "items/") {
+			return false, false
+		}
+
+		buf.WriteString("items/")
+
+		if found, tsr := m.findFromChild27(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "items/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild27(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find28(path, buf); found {
+		return found, tsr
+	}
+
+	if end, _ := m.paramEnd(path, _MatcherRegexes[4]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild31(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			return true, false
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get28(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, _MatcherRegexes[5]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get29(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "slug", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			if accept == nil || accept("/items/new-{slug:[a-z-]+}") {
+				*params = append(*params, radix.Param{Key: "slug", Value: values[0]})
+
+				return "/items/new-{slug:[a-z-]+}", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find28(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "new-") {
+			return false, false
+		}
+
+		buf.WriteString("new-")
+
+		if found, tsr := m.findFromChild28(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "new-") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild28(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, _MatcherRegexes[5]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild29(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			return true, false
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get29(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get30(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild29(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find30(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get30(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find30(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild30(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild30(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get31(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get32(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild31(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find32(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get32(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'e' {
+		if len(path) > 4 {
+			if path[:4] == "edit" {
+				if v, tsr := m.get33(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "edit" {
+			if accept == nil || accept("/items/{id:[0-9]+}/edit") {
+				return "/items/{id:[0-9]+}/edit", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find32(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild32(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild32(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find33(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get33(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get34(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find33(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "edit") {
+			return false, false
+		}
+
+		buf.WriteString("edit")
+
+		if found, tsr := m.findFromChild33(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "edit") {
+		buf.WriteString("edit")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild33(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find34(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get34(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find34(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild34(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild34(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get35(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'I' {
+		if len(path) > 5 {
+			if path[:5] == "Index" {
+				if v, tsr := m.get36(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "Index" {
+			if accept == nil || accept("/Docs/Index") {
+				return "/Docs/Index", false
+			}
+		}
+	}
+
+	if end, values := m.paramEnd(path, nil); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get38(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "page", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find35(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "Docs/") {
+			return false, false
+		}
+
+		buf.WriteString("Docs/")
+
+		if found, tsr := m.findFromChild35(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "Docs/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild35(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find36(path, buf); found {
+		return found, tsr
+	}
+
+	if end, _ := m.paramEnd(path, nil); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild38(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			buf.WriteByte('/')
+
+			return true, true
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get36(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get37(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find36(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "Index") {
+			return false, false
+		}
+
+		buf.WriteString("Index")
+
+		if found, tsr := m.findFromChild36(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "Index") {
+		buf.WriteString("Index")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild36(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find37(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get37(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find37(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild37(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild37(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get38(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get39(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			if accept == nil || accept("/Docs/{page}/") {
+				return "/Docs/{page}/", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild38(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find39(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get39(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find39(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild39(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		buf.WriteString("/")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild39(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get40(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get41(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find40(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "json") {
+			return false, false
+		}
+
+		buf.WriteString("json")
+
+		if found, tsr := m.findFromChild40(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "json") {
+		buf.WriteString("json")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild40(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find41(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get41(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find41(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild41(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild41(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get42(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get43(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find42(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "users") {
+			return false, false
+		}
+
+		buf.WriteString("users")
+
+		if found, tsr := m.findFromChild42(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "users") {
+		buf.WriteString("users")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild42(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find43(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get43(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'a' {
+		if len(path) > 5 {
+			if path[:5] == "admin" {
+				if v, tsr := m.get44(path[5:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "admin" {
+			if accept == nil || accept("/users/admin") {
+				return "/users/admin", false
+			}
+		}
+	}
+
+	if end, values := m.paramEnd(path, nil); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get46(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "name", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			if accept == nil || accept("/users/{name}") {
+				*params = append(*params, radix.Param{Key: "name", Value: values[0]})
+
+				return "/users/{name}", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find43(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild43(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild43(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find44(path, buf); found {
+		return found, tsr
+	}
+
+	if end, _ := m.paramEnd(path, nil); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild46(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			return true, false
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get44(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get45(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find44(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "admin") {
+			return false, false
+		}
+
+		buf.WriteString("admin")
+
+		if found, tsr := m.findFromChild44(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "admin") {
+		buf.WriteString("admin")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild44(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find45(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get45(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find45(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild45(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild45(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get46(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get47(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild46(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find47(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get47(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'j' {
+		if len(path) > 4 {
+			if path[:4] == "jobs" {
+				if v, tsr := m.get48(path[4:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "jobs" {
+			if accept == nil || accept("/users/{name}/jobs") {
+				return "/users/{name}/jobs", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find47(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild47(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild47(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find48(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get48(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get49(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find48(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "jobs") {
+			return false, false
+		}
+
+		buf.WriteString("jobs")
+
+		if found, tsr := m.findFromChild48(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "jobs") {
+		buf.WriteString("jobs")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild48(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find49(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get49(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find49(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild49(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild49(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get50(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get51(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			if accept == nil || accept("/data/") {
+				return "/data/", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find50(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 4 {
+		if !strings.EqualFold(path[:4], "data") {
+			return false, false
+		}
+
+		buf.WriteString("data")
+
+		if found, tsr := m.findFromChild50(path[4:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-4]
+	} else if strings.EqualFold(path, "data") {
+		buf.WriteString("data/")
+
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild50(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find51(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get51(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == 'o' {
+		if len(path) > 6 {
+			if path[:6] == "orders" {
+				if v, tsr := m.get52(path[6:], params, accept); v != nil || tsr {
+					return v, tsr
+				}
+			}
+		} else if path == "orders" {
+			if accept == nil || accept("/data/orders") {
+				return "/data/orders", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find51(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild51(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		buf.WriteString("/")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild51(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find52(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get52(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get53(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find52(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "orders") {
+			return false, false
+		}
+
+		buf.WriteString("orders")
+
+		if found, tsr := m.findFromChild52(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "orders") {
+		buf.WriteString("orders")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild52(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find53(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get53(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find53(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild53(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild53(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get54(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get55(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			if accept == nil || accept("/static/{filepath:*}") {
+				*params = append(*params, radix.Param{Key: "filepath"})
+
+				return "/static/{filepath:*}", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find54(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "static") {
+			return false, false
+		}
+
+		buf.WriteString("static")
+
+		if found, tsr := m.findFromChild54(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "static") {
+		buf.WriteString("static/")
+
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild54(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find55(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get55(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if accept == nil || accept("/static/{filepath:*}") {
+		*params = append(*params, radix.Param{Key: "filepath", Value: path})
+
+		return "/static/{filepath:*}", false
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find55(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild55(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild55(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	buf.WriteString(path)
+
+	return true, false
+}
+
+func (m Matcher) get56(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, nil); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get57(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "a", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find56(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 6 {
+		if !strings.EqualFold(path[:6], "hello/") {
+			return false, false
+		}
+
+		buf.WriteString("hello/")
+
+		if found, tsr := m.findFromChild56(path[6:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-6]
+	} else if strings.EqualFold(path, "hello/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild56(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, nil); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild57(path[end:], buf); found {
+				return found, tsr
+			}
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get57(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get58(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild57(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find58(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get58(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, nil); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get59(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "b", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find58(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild58(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild58(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, nil); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild59(path[end:], buf); found {
+				return found, tsr
+			}
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get59(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get60(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild59(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find60(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get60(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, nil); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get61(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "c", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			if accept == nil || accept("/hello/{a}/{b}/{c}") {
+				*params = append(*params, radix.Param{Key: "c", Value: values[0]})
+
+				return "/hello/{a}/{b}/{c}", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find60(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild60(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild60(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, nil); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild61(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			return true, false
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get61(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get62(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild61(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find62(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get62(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find62(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild62(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild62(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+func (m Matcher) get63(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, _MatcherRegexes[6]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get64(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "year", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find63(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 5 {
+		if !strings.EqualFold(path[:5], "blog/") {
+			return false, false
+		}
+
+		buf.WriteString("blog/")
+
+		if found, tsr := m.findFromChild63(path[5:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-5]
+	} else if strings.EqualFold(path, "blog/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild63(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, _MatcherRegexes[6]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild64(path[end:], buf); found {
+				return found, tsr
+			}
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get64(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get65(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild64(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find65(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get65(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, _MatcherRegexes[7]); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get66(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "month", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find65(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild65(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild65(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, _MatcherRegexes[7]); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild66(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			buf.WriteByte('/')
+
+			return true, true
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get66(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get67(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			if accept == nil || accept("/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/") {
+				return "/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild66(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find67(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get67(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if end, values := m.paramEnd(path, nil); end != -1 {
+		if len(path) > end {
+			v, tsr := m.get68(path[end:], params, accept)
+			if tsr {
+				return nil, true
+			} else if v != nil {
+				*params = append(*params, radix.Param{Key: "slug", Value: values[0]})
+
+				return v, false
+			}
+		} else {
+			if accept == nil || accept("/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/{slug}") {
+				*params = append(*params, radix.Param{Key: "slug", Value: values[0]})
+
+				return "/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/{slug}", false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) find67(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild67(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		buf.WriteString("/")
+
+		return true, false
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild67(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if end, _ := m.paramEnd(path, nil); end != -1 {
+		buf.WriteString(path[:end])
+
+		if len(path) > end {
+			if found, tsr := m.findFromChild68(path[end:], buf); found {
+				return found, tsr
+			}
+		} else {
+			return true, false
+		}
+
+		buf.B = buf.B[:len(buf.B)-end]
+	}
+
+	return false, false
+}
+
+func (m Matcher) get68(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	if path[0] == '/' {
+		if len(path) > 1 {
+			if v, tsr := m.get69(path[1:], params, accept); v != nil || tsr {
+				return v, tsr
+			}
+		} else if path == "/" {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m Matcher) findFromChild68(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if found, tsr := m.find69(path, buf); found {
+		return found, tsr
+	}
+
+	return false, false
+}
+
+func (m Matcher) get69(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {
+	return nil, false
+}
+
+func (m Matcher) find69(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	if len(path) > 1 {
+		if !strings.EqualFold(path[:1], "/") {
+			return false, false
+		}
+
+		buf.WriteString("/")
+
+		if found, tsr := m.findFromChild69(path[1:], buf); found {
+			return found, tsr
+		}
+
+		buf.B = buf.B[:len(buf.B)-1]
+	} else if strings.EqualFold(path, "/") {
+		return true, true
+	}
+
+	return false, false
+}
+
+func (m Matcher) findFromChild69(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
+	return false, false
+}
+
+// Values returns the values of the matcher
+func (m Matcher) Values() []interface{} {
+	return []interface{}{
+		"/",
+		"/fortune",
+		"/json",
+		"/users",
+		"/fortune-quick",
+		"/api/{file}.json",
+		"/api/prefix/files",
+		"/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/files",
+		"/api/prefix{version:V[0-9]}_{name:[a-z]+}_sufix/data",
+		"/plaintext",
+		"/prefix{name:[a-z]+}suffix/data",
+		"/prefix{name:[a-z]+}/data",
+		"/items/{id:[0-9]+}",
+		"/items/new-{slug:[a-z-]+}",
+		"/items/{id:[0-9]+}/edit",
+		"/Docs/Index",
+		"/Docs/{page}/",
+		"/users/admin",
+		"/users/{name}",
+		"/users/{name}/jobs",
+		"/data/",
+		"/data/orders",
+		"/static/{filepath:*}",
+		"/hello/{a}/{b}/{c}",
+		"/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/",
+		"/blog/{year:[0-9]{4}}/{month:[0-9]{2}}/{slug}",
+	}
+}
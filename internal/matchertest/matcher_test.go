@@ -0,0 +1,160 @@
+package matchertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router/radix"
+	"github.com/valyala/bytebufferpool"
+)
+
+var (
+	paramRe      = regexp.MustCompile(`\{([^{}:]+)(?::((?:[^{}]|\{[^{}]*\})+))?\}`)
+	sampleValues = []string{"42", "2024", "07", "V1", "abc", "a-b", "x1", "Zz", "a/b.txt"}
+)
+
+// requestPaths returns the paths of the requests of the conformance tests,
+// built from the registered paths with matching and failing param values,
+// with an extra or a missing trailing slash and in upper case
+func requestPaths() []string {
+	paths := []string{"", "x", "/", "//", "/nope", "/users/", "/USERS/Admin/", "/api/prefix", "/items/new-"}
+
+	for _, path := range Paths {
+		expanded := []string{""}
+		last := 0
+
+		for _, m := range paramRe.FindAllStringSubmatchIndex(path, -1) {
+			static := path[last:m[0]]
+			last = m[1]
+
+			var values []string
+			for _, v := range sampleValues {
+				if !strings.Contains(v, "/") || (m[4] != -1 && path[m[4]:m[5]] == "*") {
+					values = append(values, v)
+				}
+			}
+
+			next := make([]string, 0, len(expanded)*len(values))
+			for _, prefix := range expanded {
+				for _, v := range values {
+					next = append(next, prefix+static+v)
+				}
+			}
+
+			expanded = next
+		}
+
+		for _, p := range expanded {
+			p += path[last:]
+
+			paths = append(paths, p, strings.ToUpper(p), strings.TrimSuffix(p, "/"), p+"/", p+"/more")
+		}
+	}
+
+	return paths
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Router(nil).GenerateMatcher(Options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile("matcher_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(golden) != string(src) {
+		t.Errorf("matcher_gen.go is outdated, run go generate, got:\n%s", src)
+	}
+
+	treeSrc, err := Tree().Generate(Options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(treeSrc) != string(src) {
+		t.Error("the matcher generated from the tree differs from the matcher generated from the router")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	tree, matcher := Tree(), Matcher{}
+
+	rejectUsers := func(v interface{}) bool {
+		return !strings.HasPrefix(v.(string), "/users/{name}")
+	}
+
+	for _, path := range requestPaths() {
+		wantV, wantParams, wantTSR := tree.Find(path)
+		gotV, gotParams, gotTSR := matcher.Find(path)
+
+		if gotV != wantV || !reflect.DeepEqual(gotParams, wantParams) || gotTSR != wantTSR {
+			t.Errorf("Find(%q) = %v, %v, %v, want %v, %v, %v", path, gotV, gotParams, gotTSR, wantV, wantParams, wantTSR)
+		}
+
+		wantV, wantParams, wantTSR = tree.FindFunc(path, rejectUsers)
+		gotV, gotParams, gotTSR = matcher.FindFunc(path, rejectUsers)
+
+		if gotV != wantV || !reflect.DeepEqual(gotParams, wantParams) || gotTSR != wantTSR {
+			t.Errorf("FindFunc(%q) = %v, %v, %v, want %v, %v, %v", path, gotV, gotParams, gotTSR, wantV, wantParams, wantTSR)
+		}
+
+		for _, fixTrailingSlash := range []bool{false, true} {
+			wantBuf, gotBuf := bytebufferpool.Get(), bytebufferpool.Get()
+
+			wantFound := tree.FindCaseInsensitivePath(path, fixTrailingSlash, wantBuf)
+			gotFound := matcher.FindCaseInsensitivePath(path, fixTrailingSlash, gotBuf)
+
+			if gotFound != wantFound || gotBuf.String() != wantBuf.String() {
+				t.Errorf("FindCaseInsensitivePath(%q, %v) = %v, %q, want %v, %q",
+					path, fixTrailingSlash, gotFound, gotBuf, wantFound, wantBuf)
+			}
+
+			bytebufferpool.Put(wantBuf)
+			bytebufferpool.Put(gotBuf)
+		}
+	}
+}
+
+func TestRouterConformance(t *testing.T) {
+	dynamic, static := Router(nil), Router(Matcher{})
+
+	for _, path := range requestPaths() {
+		if !strings.HasPrefix(path, "/") {
+			continue
+		}
+
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut} {
+			want, got := httptest.NewRecorder(), httptest.NewRecorder()
+
+			dynamic.ServeHTTP(want, httptest.NewRequest(method, "http://example.com"+path, nil))
+			static.ServeHTTP(got, httptest.NewRequest(method, "http://example.com"+path, nil))
+
+			if got.Code != want.Code || got.Body.String() != want.Body.String() ||
+				!reflect.DeepEqual(got.Header(), want.Header()) {
+				t.Errorf("%s %s = %d %v %q, want %d %v %q", method, path,
+					got.Code, got.Header(), got.Body, want.Code, want.Header(), want.Body)
+			}
+		}
+	}
+}
+
+func TestUseMatcherUnknownPath(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Error("registering a path unknown to the matcher didn't panic")
+		}
+	}()
+
+	r := Router(Matcher{})
+	r.GET("/unknown", func(w http.ResponseWriter, r *http.Request) {})
+}
+
+var _ radix.Matcher = Matcher{}
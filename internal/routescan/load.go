@@ -0,0 +1,105 @@
+package routescan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os/exec"
+	"path/filepath"
+)
+
+// Package is a parsed and type-checked package
+type Package struct {
+	ImportPath string
+	Fset       *token.FileSet
+	Files      []*ast.File
+	Info       *types.Info
+	// Errors are the type errors of the package, which don't prevent
+	// scanning the routes of the checked code
+	Errors []error
+}
+
+// listedPackage is the output of go list -json
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	GoFiles    []string
+	CgoFiles   []string
+	Error      *struct{ Err string }
+}
+
+// Load lists the packages matching the patterns with the go command, then
+// parses and type-checks them from source
+func Load(dir string, patterns ...string) ([]*Package, error) {
+	args := append([]string{"list", "-e", "-json", "--"}, patterns...)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %v: %s", err, stderr.String())
+	}
+
+	var pkgs []*Package
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var listed listedPackage
+		if err := dec.Decode(&listed); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if listed.Error != nil {
+			return nil, fmt.Errorf("%s: %s", listed.ImportPath, listed.Error.Err)
+		}
+
+		pkg := &Package{ImportPath: listed.ImportPath, Fset: fset}
+
+		for _, name := range append(listed.GoFiles, listed.CgoFiles...) {
+			file, err := parser.ParseFile(fset, filepath.Join(listed.Dir, name), nil, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+
+			pkg.Files = append(pkg.Files, file)
+		}
+
+		pkg.Info = NewInfo()
+
+		conf := types.Config{
+			Importer: imp,
+			Error: func(err error) {
+				pkg.Errors = append(pkg.Errors, err)
+			},
+		}
+		conf.Check(listed.ImportPath, fset, pkg.Files, pkg.Info)
+
+		pkgs = append(pkgs, pkg)
+	}
+
+	return pkgs, nil
+}
+
+// NewInfo returns the type information needed by Scan
+func NewInfo() *types.Info {
+	return &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+}
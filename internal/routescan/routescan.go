@@ -0,0 +1,380 @@
+// Package routescan finds the routes registered on the routers of a
+// type-checked package, without running it.
+package routescan
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// RouterPath is the import path of the router package
+const RouterPath = "github.com/pedia/router"
+
+// UnknownPrefix replaces the prefix of the groups which can't be followed,
+// e.g. groups received as function arguments
+const UnknownPrefix = "…"
+
+// Route is a route registration found in the source
+type Route struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Name    string `json:"name,omitempty"`
+	Handler string `json:"handler"`
+	// Func is the called method, e.g. GET or HandleRoute
+	Func string         `json:"func"`
+	Pos  token.Position `json:"-"`
+	// Call is the registration call
+	Call *ast.CallExpr `json:"-"`
+	// PathArg is the path argument of the call
+	PathArg ast.Expr `json:"-"`
+	// Host is the host of the ServeMux pattern of the route, if any
+	Host string `json:"host,omitempty"`
+	// Prefix is the path of the group the route is registered on
+	Prefix string `json:"-"`
+	// Router is the variable holding the router the route is registered on,
+	// if known
+	Router types.Object `json:"-"`
+	// Dynamic reports whether the method or path of the route isn't a
+	// constant, or its group couldn't be followed
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+// registration describes the arguments of a registration method
+type registration struct {
+	method  string // the method of the shortcuts
+	path    int    // index of the path argument
+	handler int    // index of the handler argument, or -1
+	pattern bool   // whether the path argument is a ServeMux pattern
+}
+
+var registrations = map[string]registration{
+	"Handle":           {path: 1, handler: 2},
+	"HandleRoute":      {path: 1, handler: 2},
+	"HandleE":          {path: 1, handler: 2},
+	"HandleWhen":       {path: 1, handler: 2},
+	"HandleVersion":    {path: 1, handler: 3},
+	"HandleMedia":      {path: 1, handler: 3},
+	"HandlePattern":    {path: 0, handler: 1, pattern: true},
+	"HandleParams":     {path: 1, handler: 2},
+	"WS":               {method: "GET", path: 0, handler: 1},
+	"SSE":              {method: "GET", path: 0, handler: 1},
+	"ServeFiles":       {method: "GET", path: 0, handler: -1},
+	"ServeFilesCustom": {method: "GET", path: 0, handler: -1},
+}
+
+func init() {
+	for _, method := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"} {
+		registrations[method] = registration{method: method, path: 0, handler: 1}
+		registrations[method+"E"] = registration{method: method, path: 0, handler: 1}
+	}
+
+	registrations["ANY"] = registration{method: "*", path: 0, handler: 1}
+	registrations["ANYE"] = registration{method: "*", path: 0, handler: 1}
+}
+
+// base is the router and the path prefix of a router or group
+type base struct {
+	router types.Object
+	prefix string
+}
+
+// scanner follows the routers, groups and routes assigned to variables
+type scanner struct {
+	fset   *token.FileSet
+	info   *types.Info
+	bases  map[types.Object]base
+	routes map[types.Object]*Route
+	calls  map[*ast.CallExpr]*Route
+	found  []*Route
+}
+
+// Scan returns the routes registered in the files, in source order.
+// The prefixes of the groups are followed through the variables they are
+// assigned to, in source order.
+func Scan(fset *token.FileSet, files []*ast.File, info *types.Info) []*Route {
+	s := &scanner{
+		fset:   fset,
+		info:   info,
+		bases:  make(map[types.Object]base),
+		routes: make(map[types.Object]*Route),
+		calls:  make(map[*ast.CallExpr]*Route),
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, s.visit)
+	}
+
+	return s.found
+}
+
+func (s *scanner) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				s.assign(lhs, n.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		if len(n.Names) == len(n.Values) {
+			for i, name := range n.Names {
+				s.assign(name, n.Values[i])
+			}
+		}
+	case *ast.CallExpr:
+		s.call(n)
+	}
+
+	return true
+}
+
+// assign records the prefix of the group, or the route, assigned to the
+// variable
+func (s *scanner) assign(lhs, rhs ast.Expr) {
+	id, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	obj := s.info.Defs[id]
+	if obj == nil {
+		obj = s.info.Uses[id]
+	}
+
+	if obj == nil {
+		return
+	}
+
+	switch {
+	case IsRouterType(obj.Type(), "Router"), IsRouterType(obj.Type(), "Group"):
+		b := s.base(rhs)
+		if b.router == nil && IsRouterType(obj.Type(), "Router") {
+			b.router = obj
+		}
+
+		s.bases[obj] = b
+	case IsRouterType(obj.Type(), "Route"):
+		if call, ok := unparen(rhs).(*ast.CallExpr); ok {
+			s.call(call)
+			if route := s.routeOf(call); route != nil {
+				s.routes[obj] = route
+			}
+		}
+	}
+}
+
+// base returns the router and the path prefix of the router or group
+// expression
+func (s *scanner) base(expr ast.Expr) base {
+	switch e := unparen(expr).(type) {
+	case *ast.Ident:
+		if obj := s.info.Uses[e]; obj != nil {
+			if b, ok := s.bases[obj]; ok {
+				return b
+			}
+
+			if IsRouterType(obj.Type(), "Router") {
+				return base{router: obj}
+			}
+		}
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		if fn, ok := s.info.Uses[sel.Sel].(*types.Func); ok && fn.Pkg() != nil && fn.Pkg().Path() == RouterPath && fn.Name() == "New" {
+			return base{}
+		}
+
+		recv := s.info.TypeOf(sel.X)
+		if !IsRouterType(recv, "Router") && !IsRouterType(recv, "Group") {
+			break
+		}
+
+		b := s.base(sel.X)
+
+		if sel.Sel.Name != "Group" {
+			// Group methods configuring the group return it
+			return b
+		}
+
+		if len(e.Args) != 1 {
+			break
+		}
+
+		path, ok := s.constString(e.Args[0])
+		if !ok {
+			return base{router: b.router, prefix: UnknownPrefix}
+		}
+
+		if IsRouterType(recv, "Group") && len(b.prefix) > 0 && path == "/" {
+			return b
+		}
+
+		return base{router: b.router, prefix: b.prefix + path}
+	}
+
+	return base{prefix: UnknownPrefix}
+}
+
+// call records the route registered by the call, if any
+func (s *scanner) call(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	recv := s.info.TypeOf(sel.X)
+
+	switch {
+	case IsRouterType(recv, "Route") && sel.Sel.Name == "Name" && len(call.Args) == 1:
+		if route := s.routeOf(sel.X); route != nil {
+			if name, ok := s.constString(call.Args[0]); ok {
+				route.Name = name
+			}
+		}
+
+		return
+	case !IsRouterType(recv, "Router") && !IsRouterType(recv, "Group"):
+		return
+	}
+
+	reg, ok := registrations[sel.Sel.Name]
+	if !ok || len(call.Args) <= reg.path || s.calls[call] != nil {
+		return
+	}
+
+	b := s.base(sel.X)
+
+	route := &Route{
+		Method:  reg.method,
+		Func:    sel.Sel.Name,
+		Pos:     s.fset.Position(call.Pos()),
+		Call:    call,
+		PathArg: call.Args[reg.path],
+		Prefix:  b.prefix,
+		Router:  b.router,
+	}
+
+	if route.Method == "" && !reg.pattern {
+		method, ok := s.constString(call.Args[0])
+		if !ok {
+			method, route.Dynamic = "?", true
+		}
+
+		route.Method = method
+	}
+
+	path, ok := s.constString(route.PathArg)
+	switch {
+	case !ok:
+		path, route.Dynamic = "{?}", true
+
+		if reg.pattern {
+			route.Method = "?"
+		}
+	case reg.pattern:
+		route.Method, route.Host, path = splitPattern(path)
+	}
+
+	if strings.HasPrefix(route.Prefix, UnknownPrefix) {
+		route.Dynamic = true
+	}
+
+	route.Path = route.Prefix + path
+
+	if reg.handler >= 0 && len(call.Args) > reg.handler {
+		route.Handler = types.ExprString(call.Args[reg.handler])
+	}
+
+	s.calls[call] = route
+	s.found = append(s.found, route)
+}
+
+// splitPattern splits the ServeMux pattern in its method, "*" if it has
+// none, host and path
+func splitPattern(pattern string) (method, host, path string) {
+	method = "*"
+	if i := strings.IndexAny(pattern, " \t"); i != -1 {
+		method, pattern = pattern[:i], strings.TrimLeft(pattern[i+1:], " \t")
+	}
+
+	if i := strings.IndexByte(pattern, '/'); i != -1 {
+		host, pattern = pattern[:i], pattern[i:]
+	}
+
+	return method, host, pattern
+}
+
+// routeOf returns the route returned by the expression, following the
+// chained calls of the route methods and the route variables
+func (s *scanner) routeOf(expr ast.Expr) *Route {
+	for {
+		switch e := unparen(expr).(type) {
+		case *ast.Ident:
+			if obj := s.info.Uses[e]; obj != nil {
+				return s.routes[obj]
+			}
+
+			return nil
+		case *ast.CallExpr:
+			// The chained calls are visited before the registration
+			s.call(e)
+
+			if route := s.calls[e]; route != nil {
+				return route
+			}
+
+			sel, ok := e.Fun.(*ast.SelectorExpr)
+			if !ok || !IsRouterType(s.info.TypeOf(sel.X), "Route") {
+				return nil
+			}
+
+			expr = sel.X
+		default:
+			return nil
+		}
+	}
+}
+
+func (s *scanner) constString(expr ast.Expr) (string, bool) {
+	tv, ok := s.info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+// IsRouterType reports whether the type is a pointer to the named type of
+// the router package
+func IsRouterType(t types.Type, name string) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == RouterPath && obj.Name() == name
+}
+
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+
+		expr = paren.X
+	}
+}
@@ -0,0 +1,48 @@
+package routescan
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	pkgs, err := Load(".", "./testdata/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pkgs) != 1 {
+		t.Fatalf("loaded %d packages, want 1", len(pkgs))
+	}
+
+	if len(pkgs[0].Errors) > 0 {
+		t.Fatalf("type errors: %v", pkgs[0].Errors)
+	}
+
+	var got []string
+	for _, r := range Scan(pkgs[0].Fset, pkgs[0].Files, pkgs[0].Info) {
+		got = append(got, fmt.Sprintf("%s %s name=%s handler=%s func=%s line=%d dynamic=%t",
+			r.Method, r.Host+r.Path, r.Name, r.Handler, r.Func, r.Pos.Line, r.Dynamic))
+	}
+
+	want := []string{
+		"GET / name= handler=handler func=GET line=21 dynamic=false",
+		"POST /login name= handler=handler func=Handle line=22 dynamic=false",
+		"GET /api/users/{id} name=user.show handler=handler func=HandleRoute line=25 dynamic=false",
+		"DELETE /api/v1/users/{id} name= handler=handler func=DELETE line=28 dynamic=false",
+		"PUT /api/v1/admin/settings name= handler=handler func=PUT line=29 dynamic=false",
+		"GET /api/v1/items name=items.v2 handler=handler func=HandleVersion line=31 dynamic=false",
+		"? /dynamic name= handler=handler func=Handle line=35 dynamic=true",
+		"GET example.com/files/{path...} name= handler=handler func=HandlePattern line=37 dynamic=false",
+		"* /api/static/ name= handler=handler func=HandlePattern line=38 dynamic=false",
+		"GET /params/{id} name= handler=paramsHandler func=HandleParams line=39 dynamic=false",
+		"GET /api/chat/{room} name= handler=wsHandler func=WS line=40 dynamic=false",
+		"GET /events/{topic} name= handler=broker func=SSE line=41 dynamic=false",
+		"POST …/mounted name= handler=handler func=POST line=49 dynamic=true",
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("routes ==\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/pedia/router"
+)
+
+const apiPrefix = "/api"
+
+func handler(w http.ResponseWriter, r *http.Request) {}
+
+func paramsHandler(w http.ResponseWriter, r *http.Request, ps router.Params) {}
+
+func wsHandler(conn *router.WSConn, r *http.Request) {}
+
+var broker = router.NewSSEBroker(10)
+
+func Routes() *router.Router {
+	r := router.New()
+	r.GET("/", handler)
+	r.Handle(http.MethodPost, "/login", handler)
+
+	api := r.Group(apiPrefix).CaseInsensitive(true)
+	api.HandleRoute("GET", "/users/{id}", handler).Summary("user").Name("user.show")
+
+	v1 := api.Group("/v1")
+	v1.DELETE("/users/{id}", handler)
+	v1.Group("/admin").PUT("/settings", handler)
+
+	route := v1.HandleVersion("GET", "/items", "2", handler)
+	route.Name("items.v2")
+
+	var method string
+	r.Handle(method, "/dynamic", handler)
+
+	r.HandlePattern("GET example.com/files/{path...}", handler)
+	api.HandlePattern("/static/", handler)
+	r.HandleParams(http.MethodGet, "/params/{id}", paramsHandler)
+	api.WS("/chat/{room}", wsHandler)
+	r.SSE("/events/{topic}", broker)
+
+	mount(api)
+
+	return r
+}
+
+func mount(g *router.Group) {
+	g.POST("/mounted", handler)
+}
@@ -0,0 +1,100 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/pedia/router/radix"
+)
+
+// GenerateMatcher returns the Go source of a static matcher of the
+// registered paths, equivalent to the tree of the router. See
+// radix.Tree.Generate.
+//
+// Routers with many routes spend most of their startup building the
+// tree: register the routes on a router using the generated matcher with
+// UseMatcher to skip it.
+func (router *Router) GenerateMatcher(opts radix.GenerateOptions) ([]byte, error) {
+	tree := radix.New()
+
+	for _, path := range router.treePaths() {
+		tree.Insert(path, path)
+	}
+
+	opts.Value = nil
+
+	return tree.Generate(opts)
+}
+
+// UseMatcher makes the router look up the paths with the given matcher,
+// generated by GenerateMatcher, instead of building a tree.
+// It must be called before registering the routes, which must be the
+// routes the matcher was generated from. Registering a path unknown to
+// the matcher panics.
+func (router *Router) UseMatcher(m radix.Matcher) {
+	if len(router.routes) > 0 {
+		panic("the matcher must be set before registering routes")
+	}
+
+	router.matcher = m
+	router.matcherTables = make(map[string]*methodTable)
+	router.matcherPaths = nil
+
+	if values, ok := m.(interface{ Values() []interface{} }); ok {
+		router.matcherPaths = make(map[string]bool)
+
+		for _, v := range values.Values() {
+			if path, ok := v.(string); ok {
+				router.matcherPaths[path] = true
+			}
+		}
+	}
+}
+
+// treePaths returns the paths of the nodes of the tree, in insertion order
+func (router *Router) treePaths() []string {
+	seen := make(map[*methodTable]bool)
+	paths := make([]string, 0, len(router.tables))
+
+	for _, route := range router.routes {
+		ep := router.endpoints[route.method+" "+route.path]
+		if ep == nil || ep.routes[0] != route {
+			continue
+		}
+
+		for _, table := range ep.tables {
+			if !seen[table] {
+				seen[table] = true
+				paths = append(paths, table.path)
+			}
+		}
+	}
+
+	return paths
+}
+
+// insert adds the method table of the path to the tree, or to the tables
+// of the matcher if the router uses a generated matcher
+func (router *Router) insert(path string, table *methodTable) {
+	if router.matcherTables == nil {
+		router.tree.Insert(path, table)
+		return
+	}
+
+	if router.matcherPaths != nil && !router.matcherPaths[path] {
+		panic(fmt.Sprintf("path '%s' is not in the matcher, regenerate it", path))
+	}
+
+	router.matcherTables[path] = table
+}
+
+// tableOf returns the method table of a value of the matcher
+func (router *Router) tableOf(v interface{}) *methodTable {
+	switch v := v.(type) {
+	case *methodTable:
+		return v
+	case string:
+		return router.matcherTables[v]
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package router
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type negotiatedTypeKey struct{}
+
+// acceptRange is a media range of the Accept header with its quality
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// NegotiatedType returns the media type of the response negotiated for the
+// route declaring the types it produces, or an empty string
+func NegotiatedType(r *http.Request) string {
+	mediaType, _ := r.Context().Value(negotiatedTypeKey{}).(string)
+	return mediaType
+}
+
+// Consumes declares the media types of the request bodies accepted by the
+// route. The requests with another body are answered with 415 Unsupported
+// Media Type. A media type like "image/*" accepts all the subtypes.
+func (route *Route) Consumes(mediaTypes ...string) *Route {
+	route.consumes = normalizeMediaTypes(mediaTypes)
+	route.negotiate()
+
+	return route
+}
+
+// Produces declares the media types of the responses of the route, by order
+// of preference. The response type is negotiated with the Accept header of
+// the requests and returned by NegotiatedType, the requests accepting none
+// of them are answered with 406 Not Acceptable.
+func (route *Route) Produces(mediaTypes ...string) *Route {
+	route.produces = normalizeMediaTypes(mediaTypes)
+	route.negotiate()
+
+	return route
+}
+
+// Consumes declares the media types of the request bodies accepted by the
+// routes of the group and its sub-groups, unless they declare their own.
+// It must be set before registering the routes.
+func (g *Group) Consumes(mediaTypes ...string) *Group {
+	g.consumes = normalizeMediaTypes(mediaTypes)
+
+	return g
+}
+
+// Produces declares the media types of the responses of the routes of the
+// group and its sub-groups, unless they declare their own.
+// It must be set before registering the routes.
+func (g *Group) Produces(mediaTypes ...string) *Group {
+	g.produces = normalizeMediaTypes(mediaTypes)
+
+	return g
+}
+
+func normalizeMediaTypes(mediaTypes []string) []string {
+	normalized := make([]string, len(mediaTypes))
+
+	for i, mt := range mediaTypes {
+		parsed, _, err := mime.ParseMediaType(mt)
+		if err != nil {
+			panic("invalid media type '" + mt + "'")
+		}
+
+		normalized[i] = parsed
+	}
+
+	return normalized
+}
+
+// negotiate wraps the handler of the route with the content negotiation,
+// once
+func (route *Route) negotiate() {
+	if route.negotiating {
+		return
+	}
+
+	route.negotiating = true
+	route.wrap(func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			router := route.router
+
+			if len(route.consumes) > 0 && !consumable(r, route.consumes) {
+				router.unsupportedMediaType(w, r, route.path, route.consumes)
+				return
+			}
+
+			if len(route.produces) > 0 {
+				addVary(w.Header(), "Accept")
+
+				mediaType := negotiate(r, route.produces)
+				if mediaType == "" {
+					router.notAcceptable(w, r, route.path)
+					return
+				}
+
+				r = r.WithContext(context.WithValue(r.Context(), negotiatedTypeKey{}, mediaType))
+			}
+
+			handler(w, r)
+		}
+	})
+}
+
+// consumable reports whether the request has no body or a body of one of
+// the given media types
+func consumable(r *http.Request, mediaTypes []string) bool {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" && r.ContentLength == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, mt := range mediaTypes {
+		if mediaRangeMatch(mt, mediaType) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (router *Router) unsupportedMediaType(w http.ResponseWriter, r *http.Request, path string, mediaTypes []string) {
+	w.Header().Set("Accept", strings.Join(mediaTypes, ", "))
+
+	if router.UnsupportedMediaType != nil {
+		router.UnsupportedMediaType(w, r)
+		return
+	}
+
+	router.writeProblem(w, r, http.StatusUnsupportedMediaType, "", map[string]interface{}{
+		"accept":  mediaTypes,
+		"pattern": path,
+	})
+}
+
+// parseAccept returns the media ranges of the Accept headers of the request
+func parseAccept(r *http.Request) []acceptRange {
+	var ranges []acceptRange
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
+					q = f
+				}
+			}
+
+			ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+		}
+	}
+
+	return ranges
+}
+
+// negotiate returns the offered media type preferred by the request, the
+// first one if the request has no Accept header, or an empty string if the
+// request accepts none of them.
+// The quality of an offer is the one of the most specific range matching
+// it, the offers of same quality are preferred by order.
+func negotiate(r *http.Request, offers []string) string {
+	ranges := parseAccept(r)
+	if len(ranges) == 0 {
+		if len(r.Header.Values("Accept")) > 0 {
+			return ""
+		}
+
+		return offers[0]
+	}
+
+	best, bestQ := "", 0.0
+
+	for _, offer := range offers {
+		q, specificity := 0.0, 0
+
+		for _, rng := range ranges {
+			if s := mediaRangeMatch(rng.mediaType, offer); s > specificity {
+				q, specificity = rng.q, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// mediaRangeMatch returns the specificity of the media range matching the
+// media type: 1 for */*, 2 for type/*, 3 for the same type, or 0 if the
+// range doesn't match
+func mediaRangeMatch(mediaRange, mediaType string) int {
+	switch {
+	case mediaRange == mediaType:
+		return 3
+	case mediaRange == "*/*":
+		return 1
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, mediaRange[:len(mediaRange)-1]):
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,135 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouterConsumesProduces(t *testing.T) {
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(NegotiatedType(req)))
+	}
+
+	r := New()
+	r.HandleRoute(http.MethodPost, "/users", handler).
+		Consumes("application/json", "application/x-www-form-urlencoded").
+		Produces("application/json", "application/xml")
+
+	api := r.Group("/api").Consumes("application/json").Produces("application/json")
+	api.POST("/items", handler)
+	api.HandleRoute(http.MethodPost, "/images", handler).Consumes("image/*")
+
+	tests := []struct {
+		path        string
+		contentType string
+		accept      string
+		code        int
+		body        string
+	}{
+		{"/users", "application/json; charset=utf-8", "", http.StatusOK, "application/json"},
+		{"/users", "application/x-www-form-urlencoded", "application/xml", http.StatusOK, "application/xml"},
+		{"/users", "", "", http.StatusOK, "application/json"},
+		{"/users", "text/plain", "", http.StatusUnsupportedMediaType, ""},
+		{"/users", "application/json", "text/html", http.StatusNotAcceptable, ""},
+		{"/users", "application/json", "application/*;q=0.5, application/xml", http.StatusOK, "application/xml"},
+		{"/users", "application/json", "application/xml;q=0.2, */*;q=0.5", http.StatusOK, "application/json"},
+		{"/users", "application/json", "*/*, application/json;q=0", http.StatusOK, "application/xml"},
+		{"/api/items", "application/json", "application/json", http.StatusOK, "application/json"},
+		{"/api/items", "application/xml", "", http.StatusUnsupportedMediaType, ""},
+		{"/api/images", "image/png", "", http.StatusOK, "application/json"},
+		{"/api/images", "application/json", "", http.StatusUnsupportedMediaType, ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+
+		var body *strings.Reader
+		if test.contentType != "" {
+			body = strings.NewReader("{}")
+		} else {
+			body = strings.NewReader("")
+		}
+
+		req := httptest.NewRequest(http.MethodPost, test.path, body)
+		if test.contentType != "" {
+			req.Header.Set("Content-Type", test.contentType)
+		}
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("POST %s %q %q: code == %d, body == %q, want %d and %q",
+				test.path, test.contentType, test.accept, w.Code, w.Body.String(), test.code, test.body)
+		}
+
+		if test.code == http.StatusUnsupportedMediaType && w.Header().Get("Accept") == "" {
+			t.Errorf("POST %s %q: missing Accept header", test.path, test.contentType)
+		}
+
+		if test.code != http.StatusUnsupportedMediaType && w.Header().Get("Vary") != "Accept" {
+			t.Errorf("POST %s %q: Vary == %q, want Accept", test.path, test.contentType, w.Header().Get("Vary"))
+		}
+	}
+}
+
+func TestGroupConsumesProducesScope(t *testing.T) {
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+
+	r := New()
+	r.Group("/api").Consumes("application/json").Produces("application/json").POST("/users", handler)
+	r.Group("/api").POST("/items", handler)
+	r.POST("/api/orders", handler)
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/api/users", http.StatusUnsupportedMediaType},
+		{"/api/items", http.StatusOK},
+		{"/api/orders", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader("<a/>"))
+		req.Header.Set("Content-Type", "application/xml")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code {
+			t.Errorf("POST %s: status == %d, want %d", test.path, w.Code, test.code)
+		}
+	}
+}
+
+func TestRouterUnsupportedMediaType(t *testing.T) {
+	r := New()
+	r.Problems = &ProblemResponder{}
+	r.HandleRoute(http.MethodPut, "/users/{id}", func(w http.ResponseWriter, req *http.Request) {}).Consumes("application/json")
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader("a,b"))
+	req.Header.Set("Content-Type", "text/csv")
+	r.ServeHTTP(w, req)
+
+	want := `{"type":"about:blank","title":"Unsupported Media Type","status":415,"instance":"/users/1","accept":["application/json"],"pattern":"/users/{id}"}` + "\n"
+	if w.Code != http.StatusUnsupportedMediaType || w.Body.String() != want {
+		t.Errorf("code == %d, body == %q, want 415 and %q", w.Code, w.Body.String(), want)
+	}
+
+	r.UnsupportedMediaType = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot || w.Header().Get("Accept") != "application/json" {
+		t.Errorf("code == %d, Accept == %q, want %d and application/json", w.Code, w.Header().Get("Accept"), http.StatusTeapot)
+	}
+}
@@ -0,0 +1,175 @@
+package router
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+type mediaSuffixKey struct{}
+
+// defaultMediaSuffixes are the path suffixes overriding the Accept header
+// when Router.MediaSuffixes is not set
+var defaultMediaSuffixes = map[string]string{
+	".json": "application/json",
+	".xml":  "application/xml",
+	".csv":  "text/csv",
+	".html": "text/html",
+	".txt":  "text/plain",
+	".yaml": "application/yaml",
+	".pdf":  "application/pdf",
+}
+
+// HandleMedia registers a new request handler producing the given media
+// type for the path and method. Several media types may be registered for
+// the same path and method, the handler is selected by negotiation with the
+// Accept header of the request, or by the suffix of the request path, like
+// /reports/42.csv, according to Router.MediaSuffixes.
+// A route registered with Handle for the same path and method serves the
+// requests accepting none of the media types.
+// Requests accepting none of them are answered with 406 Not Acceptable
+// otherwise.
+func (router *Router) HandleMedia(method, path, mediaType string, handler http.HandlerFunc) *Route {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil || strings.Contains(parsed, "*") {
+		panic("invalid media type '" + mediaType + "'")
+	}
+
+	router.mediaVariants = true
+
+	return router.handle(&Route{method: method, path: path, handler: handler, mediaType: parsed})
+}
+
+// HandleMedia registers a new request handler producing the given media
+// type for the path and method. See Router.HandleMedia.
+func (g *Group) HandleMedia(method, path, mediaType string, handler http.HandlerFunc) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.HandleMedia(method, g.prefix+path, mediaType, handler)
+}
+
+// GetMediaType returns the media type produced by the route, if registered
+// with HandleMedia
+func (route *Route) GetMediaType() string {
+	return route.mediaType
+}
+
+// suffixMediaType returns the media type of the suffix of the path, if any
+func (router *Router) suffixMediaType(path string) (string, string) {
+	i := strings.LastIndexByte(path, '.')
+	if i == -1 || strings.IndexByte(path[i:], '/') != -1 {
+		return "", ""
+	}
+
+	suffixes := router.MediaSuffixes
+	if suffixes == nil {
+		suffixes = defaultMediaSuffixes
+	}
+
+	suffix := path[i:]
+
+	return suffix, suffixes[strings.ToLower(suffix)]
+}
+
+// serveMediaSuffix serves the request with the route registered for the
+// path without its suffix and the media type of the suffix, if any
+func (router *Router) serveMediaSuffix(w http.ResponseWriter, r *http.Request, methodIndex int, path string) bool {
+	suffix, mediaType := router.suffixMediaType(path)
+	if mediaType == "" {
+		return false
+	}
+
+	e, params := router.findEntry(methodIndex, path[:len(path)-len(suffix)])
+	if e == nil || !e.endpoint.produces(mediaType) {
+		return false
+	}
+
+	e.serve(w, r.WithContext(context.WithValue(r.Context(), mediaSuffixKey{}, mediaType)), params)
+
+	return true
+}
+
+// produces reports whether a route of the endpoint is registered for the
+// media type
+func (ep *endpoint) produces(mediaType string) bool {
+	for _, route := range ep.routes {
+		if route.mediaType == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectMedia returns the routes of the media type negotiated with the
+// request, and the request exposing the media type.
+// If no route has a media type, the routes are returned as is.
+func (ep *endpoint) selectMedia(w http.ResponseWriter, r *http.Request, routes []*Route) ([]*Route, *http.Request) {
+	var offers []string
+
+	for _, route := range routes {
+		if route.mediaType != "" && !containsString(offers, route.mediaType) {
+			offers = append(offers, route.mediaType)
+		}
+	}
+
+	if len(offers) == 0 {
+		return routes, r
+	}
+
+	addVary(w.Header(), "Accept")
+
+	mediaType := ep.suffixMediaType(r)
+	if !containsString(offers, mediaType) {
+		mediaType = negotiate(r, offers)
+	}
+
+	selected := make([]*Route, 0, len(routes))
+
+	for _, route := range routes {
+		if route.mediaType == mediaType {
+			selected = append(selected, route)
+		}
+	}
+
+	if mediaType != "" {
+		r = r.WithContext(context.WithValue(r.Context(), negotiatedTypeKey{}, mediaType))
+	}
+
+	return selected, r
+}
+
+// suffixMediaType returns the media type of the suffix of the request path.
+// If the path of the endpoint ends with a param, the suffix is removed from
+// its value.
+func (ep *endpoint) suffixMediaType(r *http.Request) string {
+	if mediaType, ok := r.Context().Value(mediaSuffixKey{}).(string); ok {
+		return mediaType
+	}
+
+	suffix, mediaType := ep.router.suffixMediaType(ep.router.routingPath(r))
+	if mediaType == "" || !ep.produces(mediaType) {
+		return ""
+	}
+
+	params := patternParams(ep.path)
+	if len(params) == 0 || !strings.HasSuffix(ep.path, "}") {
+		return ""
+	}
+
+	values := UserValues(r)
+	name := params[len(params)-1].name
+
+	value, ok := values[name]
+	if !ok || !strings.HasSuffix(value, suffix) || len(value) == len(suffix) {
+		return ""
+	}
+
+	values[name] = value[:len(value)-len(suffix)]
+	setPathValue(r, name, values[name])
+
+	return mediaType
+}
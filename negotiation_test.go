@@ -0,0 +1,110 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterHandleMedia(t *testing.T) {
+	handler := func(format string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(format + " " + UserValue(req, "id") + " " + NegotiatedType(req)))
+		}
+	}
+
+	r := New()
+	r.HandleMedia(http.MethodGet, "/reports/{id}", "text/html", handler("html"))
+	r.HandleMedia(http.MethodGet, "/reports/{id}", "text/csv", handler("csv"))
+	r.HandleMedia(http.MethodGet, "/reports/{id}", "application/json", handler("json"))
+	r.HandleMedia(http.MethodGet, "/summary", "application/json", handler("json"))
+	r.HandleMedia(http.MethodGet, "/summary", "text/csv", handler("csv"))
+	r.GET("/summary", handler("fallback"))
+
+	tests := []struct {
+		path   string
+		accept string
+		code   int
+		body   string
+	}{
+		{"/reports/42", "", http.StatusOK, "html 42 text/html"},
+		{"/reports/42", "text/csv", http.StatusOK, "csv 42 text/csv"},
+		{"/reports/42", "application/*, text/html;q=0.5", http.StatusOK, "json 42 application/json"},
+		{"/reports/42", "image/png", http.StatusNotAcceptable, ""},
+		{"/reports/42.csv", "text/html", http.StatusOK, "csv 42 text/csv"},
+		{"/reports/42.json", "", http.StatusOK, "json 42 application/json"},
+		{"/reports/42.pdf", "", http.StatusOK, "html 42.pdf text/html"},
+		{"/summary", "text/csv", http.StatusOK, "csv  text/csv"},
+		{"/summary.json", "", http.StatusOK, "json  application/json"},
+		{"/summary", "image/png", http.StatusOK, "fallback  "},
+		{"/summary.html", "", http.StatusNotFound, ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("GET %s %q: code == %d, body == %q, want %d and %q", test.path, test.accept, w.Code, w.Body.String(), test.code, test.body)
+		}
+
+		if test.code != http.StatusNotFound && w.Header().Get("Vary") != "Accept" {
+			t.Errorf("GET %s %q: Vary == %q, want Accept", test.path, test.accept, w.Header().Get("Vary"))
+		}
+	}
+}
+
+func TestRouterHandleMediaEscapedPath(t *testing.T) {
+	handler := func(format string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(format + " " + UserValue(req, "id")))
+		}
+	}
+
+	r := New()
+	r.UseEscapedPath = true
+	r.HandleMedia(http.MethodGet, "/reports/{id}", "text/html", handler("html"))
+	r.HandleMedia(http.MethodGet, "/reports/{id}", "text/csv", handler("csv"))
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/reports/42.csv", "csv 42"},
+		// The suffix is detected on the escaped path, like the route
+		{"/reports/42%2Ecsv", "html 42.csv"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if w.Body.String() != test.body {
+			t.Errorf("GET %s: body == %q, want %q", test.path, w.Body.String(), test.body)
+		}
+	}
+}
+
+func TestRouterHandleMediaConflict(t *testing.T) {
+	r := New()
+	r.HandleMedia(http.MethodGet, "/reports", "text/csv", func(w http.ResponseWriter, req *http.Request) {})
+
+	recv := catchPanic(func() {
+		r.HandleMedia(http.MethodGet, "/reports", "text/csv; charset=utf-8", func(w http.ResponseWriter, req *http.Request) {})
+	})
+	if want := "a handler for media type 'text/csv' is already registered for path '/reports'"; recv != want {
+		t.Errorf("panic == %v, want %q", recv, want)
+	}
+
+	recv = catchPanic(func() {
+		r.HandleMedia(http.MethodGet, "/reports", "text/*", func(w http.ResponseWriter, req *http.Request) {})
+	})
+	if want := "invalid media type 'text/*'"; recv != want {
+		t.Errorf("panic == %v, want %q", recv, want)
+	}
+}
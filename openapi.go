@@ -0,0 +1,227 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pedia/router/openapi"
+)
+
+// OpenAPI returns an OpenAPI 3 document describing the registered routes.
+//
+// Path patterns are translated into path templates: regex params become
+// string params with a pattern, catch-all params become a single
+// template param and optional params produce one path per variant.
+// Routes registered with MethodWild or with methods that OpenAPI can't
+// describe (e.g. CONNECT) are skipped.
+func (router *Router) OpenAPI(info openapi.Info) *openapi.Document {
+	doc := openapi.New(info)
+
+	for _, route := range router.routes {
+		if !openapi.SupportsMethod(route.method) {
+			continue
+		}
+
+		paths := getOptionalPaths(route.path)
+		if len(paths) == 0 {
+			paths = []string{route.path}
+		}
+
+		for i, path := range paths {
+			template, params, err := openAPIPath(path, route.paramDocs)
+			if err != nil {
+				continue
+			}
+
+			op := route.openAPIOperation(doc)
+			op.Parameters = append(params, route.openAPIInputs()...)
+
+			if op.OperationID != "" && len(paths) > 1 {
+				op.OperationID += "_" + strconv.Itoa(i+1)
+			}
+
+			doc.PathItem(template).SetOperation(route.method, op)
+		}
+	}
+
+	return doc
+}
+
+// OpenAPIHandler returns a handler serving the OpenAPI document of the
+// router. The document is generated on the first request, so routes
+// registered after the handler are included.
+//
+// The document is encoded as YAML if the request path ends with .yaml or
+// .yml, or if the Accept header asks for YAML, otherwise as JSON.
+func (router *Router) OpenAPIHandler(info openapi.Info) http.HandlerFunc {
+	var (
+		once       sync.Once
+		jsonDoc    []byte
+		yamlDoc    []byte
+		errEncoded error
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			doc := router.OpenAPI(info)
+
+			if jsonDoc, errEncoded = doc.JSON(); errEncoded == nil {
+				yamlDoc, errEncoded = doc.YAML()
+			}
+		})
+
+		if errEncoded != nil {
+			http.Error(w, errEncoded.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		path := r.URL.Path
+		if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") ||
+			strings.Contains(r.Header.Get("Accept"), "yaml") {
+			w.Header().Set("Content-Type", "application/yaml")
+			w.Write(yamlDoc)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jsonDoc)
+	}
+}
+
+// openAPIPath translates a route path without optional params into an
+// OpenAPI path template and its path parameters
+func openAPIPath(path string, docs map[string]string) (string, []*openapi.Parameter, error) {
+	tokens, err := parsePattern(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	template := new(strings.Builder)
+	params := make([]*openapi.Parameter, 0)
+
+	for _, tok := range tokens {
+		if tok.param == nil {
+			template.WriteString(tok.literal)
+			continue
+		}
+
+		template.WriteString("{" + tok.param.name + "}")
+
+		param := &openapi.Parameter{
+			Name:        tok.param.name,
+			In:          "path",
+			Description: docs[tok.param.name],
+			Required:    true,
+			Schema:      &openapi.Schema{Type: "string"},
+		}
+
+		switch {
+		case tok.param.catchAll:
+			if param.Description == "" {
+				param.Description = "Remaining path, may contain slashes"
+			}
+		case tok.param.regex != "":
+			param.Schema.Pattern = anchoredRegex(tok.param.regex)
+		}
+
+		params = append(params, param)
+	}
+
+	return template.String(), params, nil
+}
+
+// anchoredRegex returns the regex anchored to match the whole value
+func anchoredRegex(regex string) string {
+	if !strings.HasPrefix(regex, "^") {
+		regex = "^(?:" + regex + ")"
+	}
+
+	if !strings.HasSuffix(regex, "$") {
+		regex += "$"
+	}
+
+	return regex
+}
+
+func (route *Route) openAPIOperation(doc *openapi.Document) *openapi.Operation {
+	op := &openapi.Operation{
+		Tags:        route.tags,
+		Summary:     route.summary,
+		Description: route.description,
+		OperationID: route.name,
+		Deprecated:  route.deprecated,
+		Responses:   make(map[string]*openapi.Response),
+	}
+
+	if route.requestBody != nil {
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: doc.SchemaOf(route.requestBody)},
+			},
+		}
+	}
+
+	for _, resp := range route.responses {
+		description := resp.description
+		if description == "" {
+			description = http.StatusText(resp.code)
+		}
+
+		r := &openapi.Response{Description: description}
+		if resp.body != nil {
+			r.Content = map[string]*openapi.MediaType{
+				"application/json": {Schema: doc.SchemaOf(resp.body)},
+			}
+		}
+
+		op.Responses[strconv.Itoa(resp.code)] = r
+	}
+
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &openapi.Response{Description: http.StatusText(http.StatusOK)}
+	}
+
+	return op
+}
+
+// openAPIInputs returns the parameters of the query params and headers
+// declared for the route
+func (route *Route) openAPIInputs() []*openapi.Parameter {
+	params := make([]*openapi.Parameter, 0, len(route.inputs))
+
+	for _, input := range route.inputs {
+		schema := &openapi.Schema{Type: "string"}
+		if input.Min != nil || input.Max != nil {
+			schema.Type = "number"
+		}
+
+		schema.Minimum, schema.Maximum = input.Min, input.Max
+
+		for _, value := range input.Enum {
+			schema.Enum = append(schema.Enum, value)
+		}
+
+		if input.Pattern != nil {
+			schema.Pattern = input.Pattern.String()
+		}
+
+		if input.MaxLength > 0 {
+			maxLength := input.MaxLength
+			schema.MaxLength = &maxLength
+		}
+
+		params = append(params, &openapi.Parameter{
+			Name:        input.Name,
+			In:          input.In,
+			Description: input.Description,
+			Required:    input.Required,
+			Schema:      schema,
+		})
+	}
+
+	return params
+}
@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	componentsSchemasPrefix    = "#/components/schemas/"
+	componentsParametersPrefix = "#/components/parameters/"
+)
+
+// New returns an empty document with the given info
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+}
+
+// Parse decodes a JSON encoded OpenAPI 3 document
+func Parse(data []byte) (*Document, error) {
+	doc := new(Document)
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Paths == nil {
+		doc.Paths = make(map[string]*PathItem)
+	}
+
+	return doc, nil
+}
+
+// JSON returns the indented JSON encoding of the document
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the YAML encoding of the document
+func (d *Document) YAML() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonToYAML(data)
+}
+
+// PathItem returns the path item for the given path template, adding an
+// empty one if missing
+func (d *Document) PathItem(path string) *PathItem {
+	if d.Paths == nil {
+		d.Paths = make(map[string]*PathItem)
+	}
+
+	item := d.Paths[path]
+	if item == nil {
+		item = new(PathItem)
+		d.Paths[path] = item
+	}
+
+	return item
+}
+
+// Resolve follows the $ref of the given schema within the document
+// components. Schemas without reference are returned as is.
+func (d *Document) Resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		if d.Components == nil || !strings.HasPrefix(s.Ref, componentsSchemasPrefix) {
+			return nil
+		}
+
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, componentsSchemasPrefix)]
+	}
+
+	return s
+}
+
+// ResolveParameter follows the $ref of the given parameter within the
+// document components. Parameters without reference are returned as is.
+func (d *Document) ResolveParameter(p *Parameter) *Parameter {
+	for p != nil && p.Ref != "" {
+		if d.Components == nil || !strings.HasPrefix(p.Ref, componentsParametersPrefix) {
+			return nil
+		}
+
+		p = d.Components.Parameters[strings.TrimPrefix(p.Ref, componentsParametersPrefix)]
+	}
+
+	return p
+}
+
+func (d *Document) addSchema(name string, s *Schema) {
+	if d.Components == nil {
+		d.Components = new(Components)
+	}
+
+	if d.Components.Schemas == nil {
+		d.Components.Schemas = make(map[string]*Schema)
+	}
+
+	d.Components.Schemas[name] = s
+}
@@ -0,0 +1,190 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// SchemaOf returns the schema describing the JSON encoding of the given
+// value, as produced by encoding/json.
+//
+// Named struct types are registered once in the document components and
+// referenced with $ref, so recursive types are supported.
+// A reflect.Type may be passed instead of a value.
+func (d *Document) SchemaOf(v interface{}) *Schema {
+	if v == nil {
+		return nil
+	}
+
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+
+	return d.schemaOf(t)
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	if t.Kind() != reflect.Ptr && t.Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := d.schemaOf(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+
+		s.Nullable = true
+
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// encoding/json encodes the byte slices, not the arrays, in base64
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+
+		name := d.schemaName(t)
+		if d.Components == nil || d.Components.Schemas[name] == nil {
+			// Register a placeholder first to stop the recursion
+			d.addSchema(name, &Schema{})
+			*d.Components.Schemas[name] = *d.structSchema(t)
+		}
+
+		return &Schema{Ref: componentsSchemasPrefix + name}
+	}
+
+	// interface{} and unsupported kinds accept any value
+	return &Schema{}
+}
+
+// schemaName returns the components name of a named type, disambiguating
+// types with the same name from different packages
+func (d *Document) schemaName(t reflect.Type) string {
+	if d.schemaTypes == nil {
+		d.schemaTypes = make(map[reflect.Type]string)
+	}
+
+	if name, ok := d.schemaTypes[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	for _, registered := range d.schemaTypes {
+		if registered == name {
+			pkg := path.Base(t.PkgPath())
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+			break
+		}
+	}
+
+	d.schemaTypes[t] = name
+
+	return name
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	d.structFields(t, s)
+
+	return s
+}
+
+func (d *Document) structFields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts := parseJSONTag(f.Tag.Get("json"))
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				d.structFields(ft, s)
+				continue
+			}
+		}
+
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fs := d.schemaOf(f.Type)
+		if strings.Contains(opts, "string") {
+			fs = &Schema{Type: "string", Format: fs.Format}
+		}
+
+		if desc := f.Tag.Get("description"); desc != "" {
+			if fs.Ref != "" {
+				// Siblings of $ref are ignored by OpenAPI 3.0
+				fs = &Schema{Ref: fs.Ref}
+			} else {
+				fs.Description = desc
+			}
+		}
+
+		s.Properties[name] = fs
+
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func parseJSONTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i != -1 {
+		return tag[:i], tag[i+1:]
+	}
+
+	return tag, ""
+}
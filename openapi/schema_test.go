@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+)
+
+type node struct {
+	Name     string    `json:"name"`
+	Created  time.Time `json:"created"`
+	Parent   *node     `json:"parent,omitempty"`
+	Children []node    `json:"children"`
+	Ignored  string    `json:"-"`
+	private  string
+}
+
+func TestDocumentSchemaOf(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+
+	s := doc.SchemaOf(&node{})
+	if s.Ref != "#/components/schemas/node" {
+		t.Fatalf("Ref == %q", s.Ref)
+	}
+
+	n := doc.Resolve(s)
+	if n == nil || n.Type != "object" {
+		t.Fatalf("unexpected resolved schema: %+v", n)
+	}
+
+	if len(n.Properties) != 4 {
+		t.Errorf("len(Properties) == %d, want 4", len(n.Properties))
+	}
+
+	if n.Properties["created"].Format != "date-time" {
+		t.Errorf("created: %+v", n.Properties["created"])
+	}
+
+	if n.Properties["parent"].Ref != s.Ref || n.Properties["children"].Items.Ref != s.Ref {
+		t.Error("recursive references expected")
+	}
+
+	if len(n.Required) != 3 {
+		t.Errorf("Required == %v", n.Required)
+	}
+
+	if s := doc.SchemaOf(map[string][]byte{}); s.Type != "object" || s.AdditionalProperties.Format != "byte" {
+		t.Errorf("unexpected map schema: %+v", s)
+	}
+
+	if s := doc.SchemaOf(struct{ IP [4]byte }{}); s.Properties["IP"].Type != "array" || s.Properties["IP"].Items.Type != "integer" {
+		t.Errorf("unexpected byte array schema: %+v", s.Properties["IP"])
+	}
+}
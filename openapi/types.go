@@ -0,0 +1,189 @@
+package openapi
+
+import "reflect"
+
+// Version is the OpenAPI specification version of generated documents
+const Version = "3.0.3"
+
+// Document is the root object of an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty"`
+
+	schemaTypes map[reflect.Type]string
+}
+
+// Info provides metadata about the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a server hosting the API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag adds metadata to a tag used by operations
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds reusable objects of the document
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas,omitempty"`
+	Parameters map[string]*Parameter `json:"parameters,omitempty"`
+}
+
+// PathItem describes the operations available on a single path
+type PathItem struct {
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Get         *Operation   `json:"get,omitempty"`
+	Put         *Operation   `json:"put,omitempty"`
+	Post        *Operation   `json:"post,omitempty"`
+	Delete      *Operation   `json:"delete,omitempty"`
+	Options     *Operation   `json:"options,omitempty"`
+	Head        *Operation   `json:"head,omitempty"`
+	Patch       *Operation   `json:"patch,omitempty"`
+	Trace       *Operation   `json:"trace,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
+}
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationID string               `json:"operationId,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+}
+
+// Parameter describes a single operation parameter
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes a single request body
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response describes a single response from an API operation
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header describes a single response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// MediaType provides the schema for a media type
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema is the subset of the OpenAPI schema object supported by this package
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// SupportsMethod reports whether operations for the given upper-case HTTP
+// method can be described by a path item
+func SupportsMethod(method string) bool {
+	switch method {
+	case "GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE":
+		return true
+	}
+
+	return false
+}
+
+// Methods returns the operations of the path item keyed by upper-case
+// HTTP method
+func (p *PathItem) Methods() map[string]*Operation {
+	ops := make(map[string]*Operation)
+
+	for method, op := range map[string]*Operation{
+		"GET":     p.Get,
+		"PUT":     p.Put,
+		"POST":    p.Post,
+		"DELETE":  p.Delete,
+		"OPTIONS": p.Options,
+		"HEAD":    p.Head,
+		"PATCH":   p.Patch,
+		"TRACE":   p.Trace,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+
+	return ops
+}
+
+// SetOperation sets the operation for the given upper-case HTTP method.
+// It returns false if the method can't be described by a path item.
+func (p *PathItem) SetOperation(method string, op *Operation) bool {
+	switch method {
+	case "GET":
+		p.Get = op
+	case "PUT":
+		p.Put = op
+	case "POST":
+		p.Post = op
+	case "DELETE":
+		p.Delete = op
+	case "OPTIONS":
+		p.Options = op
+	case "HEAD":
+		p.Head = op
+	case "PATCH":
+		p.Patch = op
+	case "TRACE":
+		p.Trace = op
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,283 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Violation is a value not satisfying a schema
+type Violation struct {
+	// Path is the JSON pointer of the invalid value, empty for the root value
+	Path    string
+	Message string
+}
+
+func (v Violation) Error() string {
+	if v.Path == "" {
+		return v.Message
+	}
+
+	return v.Path + ": " + v.Message
+}
+
+var patternCache sync.Map
+
+// Validate checks the given decoded JSON value against the schema.
+// Numbers may be float64 or json.Number values.
+func (d *Document) Validate(s *Schema, v interface{}) []Violation {
+	return d.validate(s, v, "", nil)
+}
+
+func (d *Document) validate(s *Schema, v interface{}, path string, vs []Violation) []Violation {
+	s = d.Resolve(s)
+	if s == nil {
+		return vs
+	}
+
+	fail := func(format string, args ...interface{}) []Violation {
+		return append(vs, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if v == nil {
+		if s.Nullable || s.Type == "" {
+			return vs
+		}
+
+		return fail("must not be null")
+	}
+
+	if len(s.Enum) > 0 && !enumContains(s.Enum, v) {
+		return fail("must be one of %s", formatEnum(s.Enum))
+	}
+
+	switch s.Type {
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fail("must be a string")
+		}
+
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			vs = fail("must be at least %d characters long", *s.MinLength)
+		}
+
+		if s.MaxLength != nil && n > *s.MaxLength {
+			vs = fail("must be at most %d characters long", *s.MaxLength)
+		}
+
+		if s.Pattern != "" {
+			re, err := compilePattern(s.Pattern)
+			if err != nil {
+				return fail("invalid pattern in schema: %v", err)
+			}
+
+			if !re.MatchString(str) {
+				vs = fail("must match the pattern %s", s.Pattern)
+			}
+		}
+
+	case "integer", "number":
+		f, ok := toFloat(v)
+		if !ok {
+			return fail("must be a %s", s.Type)
+		}
+
+		if s.Type == "integer" && f != math.Trunc(f) {
+			return fail("must be an integer")
+		}
+
+		if s.Minimum != nil && f < *s.Minimum {
+			vs = fail("must be greater than or equal to %v", *s.Minimum)
+		}
+
+		if s.Maximum != nil && f > *s.Maximum {
+			vs = fail("must be less than or equal to %v", *s.Maximum)
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fail("must be a boolean")
+		}
+
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			return fail("must be an array")
+		}
+
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			vs = fail("must contain at least %d items", *s.MinItems)
+		}
+
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			vs = fail("must contain at most %d items", *s.MaxItems)
+		}
+
+		for i, item := range items {
+			vs = d.validate(s.Items, item, path+"/"+strconv.Itoa(i), vs)
+		}
+
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fail("must be an object")
+		}
+
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				vs = append(vs, Violation{Path: path + "/" + name, Message: "is required"})
+			}
+		}
+
+		for _, name := range sortedKeys(obj) {
+			if ps, ok := s.Properties[name]; ok {
+				vs = d.validate(ps, obj[name], path+"/"+name, vs)
+			} else if s.AdditionalProperties != nil {
+				vs = d.validate(s.AdditionalProperties, obj[name], path+"/"+name, vs)
+			}
+		}
+	}
+
+	return vs
+}
+
+// ParseParam converts the raw values of a parameter to the type described
+// by the schema, so it can be validated.
+// Array values may be given as repeated values or as a single comma
+// separated value.
+func (d *Document) ParseParam(s *Schema, values []string) (interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	s = d.Resolve(s)
+	if s == nil {
+		return values[0], nil
+	}
+
+	if s.Type == "array" {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			item, err := d.ParseParam(s.Items, []string{value})
+			if err != nil {
+				return nil, err
+			}
+
+			items[i] = item
+		}
+
+		return items, nil
+	}
+
+	value := values[0]
+
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("must be an integer")
+		}
+
+		return json.Number(value), nil
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+
+		return json.Number(value), nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+
+		return b, nil
+	}
+
+	return value, nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	patternCache.Store(pattern, re)
+
+	return re, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+
+	return 0, false
+}
+
+func enumContains(enum []interface{}, v interface{}) bool {
+	f, isNumber := toFloat(v)
+
+	for _, e := range enum {
+		if isNumber {
+			if ef, ok := toFloat(e); ok && ef == f {
+				return true
+			}
+
+			continue
+		}
+
+		switch v.(type) {
+		case string, bool:
+			if e == v {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = fmt.Sprint(e)
+	}
+
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlValue is a JSON value which keeps the order of the object keys
+type yamlValue struct {
+	keys   []string
+	fields []*yamlValue
+	items  []*yamlValue
+	scalar interface{}
+
+	isObject bool
+	isArray  bool
+}
+
+var yamlPlainRe = regexp.MustCompile(`^[A-Za-z_/$][A-Za-z0-9_ ./$(){}+-]*$`)
+
+// jsonToYAML converts JSON encoded data to YAML keeping the order of the keys
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	v, err := decodeYAMLValue(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	writeYAMLValue(buf, v, 0, false)
+
+	return buf.Bytes(), nil
+}
+
+func decodeYAMLValue(dec *json.Decoder) (*yamlValue, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		v := &yamlValue{isObject: true}
+
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			field, err := decodeYAMLValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			v.keys = append(v.keys, key.(string))
+			v.fields = append(v.fields, field)
+		}
+
+		_, err = dec.Token() // '}'
+
+		return v, err
+
+	case json.Delim('['):
+		v := &yamlValue{isArray: true}
+
+		for dec.More() {
+			item, err := decodeYAMLValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			v.items = append(v.items, item)
+		}
+
+		_, err = dec.Token() // ']'
+
+		return v, err
+	}
+
+	return &yamlValue{scalar: tok}, nil
+}
+
+func writeYAMLValue(w io.Writer, v *yamlValue, indent int, inline bool) {
+	pad := strings.Repeat("  ", indent)
+
+	switch {
+	case v.isObject:
+		if len(v.keys) == 0 {
+			io.WriteString(w, "{}\n")
+			return
+		}
+
+		for i, key := range v.keys {
+			if i > 0 || !inline {
+				io.WriteString(w, pad)
+			}
+
+			io.WriteString(w, yamlScalar(key)+":")
+			writeYAMLChild(w, v.fields[i], indent+1)
+		}
+
+	case v.isArray:
+		if len(v.items) == 0 {
+			io.WriteString(w, "[]\n")
+			return
+		}
+
+		for i, item := range v.items {
+			if i > 0 || !inline {
+				io.WriteString(w, pad)
+			}
+
+			io.WriteString(w, "- ")
+
+			if item.isObject && len(item.keys) > 0 {
+				writeYAMLValue(w, item, indent+1, true)
+			} else if item.isArray && len(item.items) > 0 {
+				io.WriteString(w, "\n")
+				writeYAMLValue(w, item, indent+1, false)
+			} else {
+				writeYAMLValue(w, item, indent+1, true)
+			}
+		}
+
+	default:
+		io.WriteString(w, yamlScalar(v.scalar)+"\n")
+	}
+}
+
+func writeYAMLChild(w io.Writer, v *yamlValue, indent int) {
+	if (v.isObject && len(v.keys) > 0) || (v.isArray && len(v.items) > 0) {
+		io.WriteString(w, "\n")
+		writeYAMLValue(w, v, indent, false)
+
+		return
+	}
+
+	io.WriteString(w, " ")
+	writeYAMLValue(w, v, indent, true)
+}
+
+func yamlScalar(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(s)
+	case json.Number:
+		return s.String()
+	case string:
+		switch strings.ToLower(s) {
+		case "", "true", "false", "yes", "no", "on", "off", "null", "y", "n", "~":
+			return strconv.Quote(s)
+		}
+
+		if yamlPlainRe.MatchString(s) && !strings.HasSuffix(s, " ") {
+			return s
+		}
+
+		return strconv.Quote(s)
+	}
+
+	return strconv.Quote(fmt.Sprint(v))
+}
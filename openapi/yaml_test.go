@@ -0,0 +1,29 @@
+package openapi
+
+import "testing"
+
+func TestJSONToYAML(t *testing.T) {
+	data := `{"b":1,"a":{"list":[1,"two",{"k":"v","x":[]}],"empty":{}},"s":"yes","n":null,"r":"#/ref: x"}`
+
+	want := `b: 1
+a:
+  list:
+    - 1
+    - two
+    - k: v
+      x: []
+  empty: {}
+s: "yes"
+"n": null
+r: "#/ref: x"
+`
+
+	got, err := jsonToYAML([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(got) != want {
+		t.Errorf("jsonToYAML() ==\n%s\nwant\n%s", got, want)
+	}
+}
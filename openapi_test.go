@@ -0,0 +1,139 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router/openapi"
+)
+
+type openAPIUser struct {
+	ID      int64    `json:"id"`
+	Name    string   `json:"name" description:"Full name"`
+	Email   string   `json:"email,omitempty"`
+	Friends []string `json:"friends,omitempty"`
+}
+
+func TestRouterOpenAPI(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	v1 := r.Group("/v1")
+	v1.HandleRoute(http.MethodGet, "/users/{id:[0-9]+}", handler).
+		Name("getUser").
+		Summary("Get a user").
+		Tags("users").
+		Param("id", "User identifier").
+		Response(http.StatusOK, "", openAPIUser{}).
+		Response(http.StatusNotFound, "No such user", nil)
+	v1.HandleRoute(http.MethodPost, "/users", handler).
+		Name("createUser").
+		Request(&openAPIUser{}).
+		Response(http.StatusCreated, "Created", openAPIUser{})
+	v1.HandleRoute(http.MethodGet, "/files/{path:*}", handler)
+	v1.HandleRoute(http.MethodDelete, "/posts/{id?}", handler).Name("deletePosts")
+	r.ANY("/any", handler)
+	r.CONNECT("/connect", handler)
+
+	doc := r.OpenAPI(openapi.Info{Title: "test", Version: "1.0"})
+
+	wantPaths := []string{"/v1/users/{id}", "/v1/users", "/v1/files/{path}", "/v1/posts", "/v1/posts/{id}"}
+	if len(doc.Paths) != len(wantPaths) {
+		t.Errorf("len(Paths) == %d, want %d", len(doc.Paths), len(wantPaths))
+	}
+
+	for _, path := range wantPaths {
+		if doc.Paths[path] == nil {
+			t.Errorf("missing path %s", path)
+		}
+	}
+
+	op := doc.Paths["/v1/users/{id}"].Get
+	if op == nil {
+		t.Fatal("missing GET /v1/users/{id}")
+	}
+
+	if op.OperationID != "getUser" || op.Summary != "Get a user" || len(op.Tags) != 1 {
+		t.Errorf("unexpected operation: %+v", op)
+	}
+
+	if len(op.Parameters) != 1 {
+		t.Fatalf("len(Parameters) == %d, want 1", len(op.Parameters))
+	}
+
+	param := op.Parameters[0]
+	if param.Name != "id" || param.In != "path" || !param.Required || param.Description != "User identifier" {
+		t.Errorf("unexpected parameter: %+v", param)
+	}
+
+	if param.Schema.Pattern != "^(?:[0-9]+)$" {
+		t.Errorf("Pattern == %q, want %q", param.Schema.Pattern, "^(?:[0-9]+)$")
+	}
+
+	if ref := op.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/openAPIUser" {
+		t.Errorf("response $ref == %q", ref)
+	}
+
+	if op.Responses["404"].Description != "No such user" {
+		t.Errorf("unexpected 404 response: %+v", op.Responses["404"])
+	}
+
+	user := doc.Components.Schemas["openAPIUser"]
+	if user == nil {
+		t.Fatal("missing openAPIUser schema")
+	}
+
+	if strings.Join(user.Required, ",") != "id,name" {
+		t.Errorf("Required == %v, want [id name]", user.Required)
+	}
+
+	if user.Properties["friends"].Items.Type != "string" || user.Properties["id"].Format != "int64" {
+		t.Errorf("unexpected properties: %+v", user.Properties)
+	}
+
+	post := doc.Paths["/v1/users"].Post
+	if post.RequestBody == nil || post.RequestBody.Content["application/json"].Schema.Ref == "" {
+		t.Errorf("unexpected request body: %+v", post.RequestBody)
+	}
+
+	if doc.Paths["/v1/posts"].Delete.OperationID == doc.Paths["/v1/posts/{id}"].Delete.OperationID {
+		t.Error("operationId of optional path variants must be unique")
+	}
+}
+
+func TestRouterOpenAPIHandler(t *testing.T) {
+	r := New()
+	r.GET("/openapi.json", r.OpenAPIHandler(openapi.Info{Title: "test", Version: "1.0"}))
+	r.GET("/openapi.yaml", r.OpenAPIHandler(openapi.Info{Title: "test", Version: "1.0"}))
+	r.GET("/ping", func(w http.ResponseWriter, r *http.Request) {})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type == %q, want %q", ct, "application/json")
+	}
+
+	doc := make(map[string]interface{})
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if paths := doc["paths"].(map[string]interface{}); paths["/ping"] == nil {
+		t.Errorf("missing /ping in %v", paths)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/yaml" {
+		t.Errorf("Content-Type == %q, want %q", ct, "application/yaml")
+	}
+
+	if body := w.Body.String(); !strings.HasPrefix(body, "openapi: \"3.0.3\"\n") || !strings.Contains(body, "\n  /ping:\n") {
+		t.Errorf("unexpected YAML document:\n%s", body)
+	}
+}
@@ -1,14 +1,31 @@
 package router
 
 import (
+	"path"
 	"strings"
 
 	gstrings "github.com/savsgio/gotils/strings"
 )
 
-// cleanPath removes the '.' if it is the last character of the route
-func cleanPath(path string) string {
-	return strings.TrimSuffix(path, ".")
+// cleanPath returns the canonical form of the path: rooted, without '.'
+// and '..' elements and without doubled slashes.
+// The trailing slash is kept, and added if the last element is '.' or '..'.
+func cleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	if p[0] != '/' {
+		p = "/" + p
+	}
+
+	cleaned := path.Clean(p)
+
+	if cleaned != "/" && (strings.HasSuffix(p, "/") || strings.HasSuffix(p, "/.") || strings.HasSuffix(p, "/..")) {
+		cleaned += "/"
+	}
+
+	return cleaned
 }
 
 // getOptionalPaths returns all possible paths when the original path
@@ -66,10 +66,6 @@ var cleanTests = []cleanPathTest{
 }
 
 func Test_cleanPath(t *testing.T) {
-	// if runtime.GOOS == "windows" {
-	t.SkipNow()
-	// }
-
 	req := httptest.NewRequest("GET", "/", nil)
 	uri := req.URL
 
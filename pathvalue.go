@@ -0,0 +1,11 @@
+//go:build go1.22
+// +build go1.22
+
+package router
+
+import "net/http"
+
+// setPathValue sets the value of the param for Request.PathValue
+func setPathValue(r *http.Request, key, value string) {
+	r.SetPathValue(key, value)
+}
@@ -0,0 +1,9 @@
+//go:build !go1.22
+// +build !go1.22
+
+package router
+
+import "net/http"
+
+// setPathValue does nothing, Request.PathValue requires Go 1.22
+func setPathValue(r *http.Request, key, value string) {}
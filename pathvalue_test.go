@@ -0,0 +1,47 @@
+//go:build go1.22
+// +build go1.22
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPathValue(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("id") + " " + r.PathValue("path") + " " + UserValue(r, "id")))
+	}
+
+	r := New()
+	r.HandlePattern("GET /users/{id}/files/{path...}", handler)
+	r.GET("/items/{id:[0-9]+}", handler)
+	r.HandlePattern("/static/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue(subtreeParam)))
+	})
+
+	escaped := New()
+	escaped.UseEscapedPath = true
+	escaped.GET("/docs/{id}", handler)
+
+	tests := []struct {
+		router *Router
+		url    string
+		body   string
+	}{
+		{r, "/users/7/files/a/b.txt", "7 a/b.txt 7"},
+		{r, "/items/42", "42  42"},
+		{r, "/static/css/site.css", ""},
+		{escaped, "/docs/a%2Fb", "a/b  a/b"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		test.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.url, nil))
+
+		if w.Body.String() != test.body {
+			t.Errorf("%s: body %q, want %q", test.url, w.Body, test.body)
+		}
+	}
+}
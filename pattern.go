@@ -0,0 +1,145 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+)
+
+// patternParam describes a single {param} declaration of a route pattern
+type patternParam struct {
+	name     string
+	regex    string
+	catchAll bool
+	optional bool
+}
+
+// patternToken is either a literal chunk of a route pattern or a param
+type patternToken struct {
+	literal string
+	param   *patternParam
+}
+
+// parsePattern splits a route pattern into literal chunks and params.
+//
+// Supported param forms are {name}, {name?}, {name:regex}, {name?:regex}
+// and {name:*}. Braces inside the regex part are balanced, so patterns like
+// {id:[0-9]{3}} are allowed.
+func parsePattern(path string) ([]patternToken, error) {
+	tokens := make([]patternToken, 0)
+
+	start := 0
+	for i := 0; i < len(path); i++ {
+		if path[i] == '}' {
+			return nil, fmt.Errorf("unexpected '}' at offset %d in path '%s'", i, path)
+		}
+
+		if path[i] != '{' {
+			continue
+		}
+
+		if i > start {
+			tokens = append(tokens, patternToken{literal: path[start:i]})
+		}
+
+		end, param, err := parsePatternParam(path, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(tokens); n > 0 && tokens[n-1].param != nil {
+			return nil, fmt.Errorf("the wildcards must be separated by at least 1 char in path '%s'", path)
+		}
+
+		tokens = append(tokens, patternToken{param: param})
+
+		i = end
+		start = end + 1
+	}
+
+	if start < len(path) {
+		tokens = append(tokens, patternToken{literal: path[start:]})
+	}
+
+	for i, tok := range tokens {
+		if tok.param != nil && tok.param.catchAll && i != len(tokens)-1 {
+			return nil, fmt.Errorf("wildcard routes are only allowed at the end of the path in path '%s'", path)
+		}
+	}
+
+	return tokens, nil
+}
+
+// parsePatternParam parses the param starting at path[start] == '{' and
+// returns the index of its closing brace
+func parsePatternParam(path string, start int) (int, *patternParam, error) {
+	colon := -1
+	depth := 0
+
+	for i := start + 1; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == ':' && colon == -1:
+			colon = i
+		case c == '{':
+			if colon == -1 {
+				return 0, nil, fmt.Errorf("the char '{' is not allowed in the param name in path '%s'", path)
+			}
+
+			depth++
+		case c == '}':
+			if depth > 0 {
+				depth--
+				continue
+			}
+
+			param := new(patternParam)
+
+			name := path[start+1 : i]
+			if colon != -1 {
+				name = path[start+1 : colon]
+				param.regex = path[colon+1 : i]
+			}
+
+			if strings.HasSuffix(name, "?") {
+				name = name[:len(name)-1]
+				param.optional = true
+			}
+
+			if param.regex == "*" {
+				param.regex = ""
+				param.catchAll = true
+			}
+
+			switch {
+			case len(name) == 0:
+				return 0, nil, fmt.Errorf("wildcards must be named with a non-empty name in path '%s'", path)
+			case strings.ContainsAny(name, "/?:"):
+				return 0, nil, fmt.Errorf("invalid param name '%s' in path '%s'", name, path)
+			case colon != -1 && len(param.regex) == 0 && !param.catchAll:
+				return 0, nil, fmt.Errorf("empty regex for param '%s' in path '%s'", name, path)
+			}
+
+			param.name = name
+
+			return i, param, nil
+		}
+	}
+
+	return 0, nil, fmt.Errorf("unclosed '{' at offset %d in path '%s'", start, path)
+}
+
+// patternParams returns the params declared in the given route pattern
+func patternParams(path string) []*patternParam {
+	tokens, err := parsePattern(path)
+	if err != nil {
+		return nil
+	}
+
+	params := make([]*patternParam, 0, len(tokens))
+	for _, tok := range tokens {
+		if tok.param != nil {
+			params = append(params, tok.param)
+		}
+	}
+
+	return params
+}
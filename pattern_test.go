@@ -0,0 +1,62 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		path   string
+		tokens []patternToken
+	}{
+		{"/hello", []patternToken{{literal: "/hello"}}},
+		{"/{name}", []patternToken{{literal: "/"}, {param: &patternParam{name: "name"}}}},
+		{"/user/{user}_admin", []patternToken{
+			{literal: "/user/"},
+			{param: &patternParam{name: "user"}},
+			{literal: "_admin"},
+		}},
+		{"/{id:[0-9]{3}}/x", []patternToken{
+			{literal: "/"},
+			{param: &patternParam{name: "id", regex: "[0-9]{3}"}},
+			{literal: "/x"},
+		}},
+		{"/{name?:[a-z]+}", []patternToken{
+			{literal: "/"},
+			{param: &patternParam{name: "name", regex: "[a-z]+", optional: true}},
+		}},
+		{"/static/{filepath:*}", []patternToken{
+			{literal: "/static/"},
+			{param: &patternParam{name: "filepath", catchAll: true}},
+		}},
+	}
+
+	for _, test := range tests {
+		tokens, err := parsePattern(test.path)
+		if err != nil {
+			t.Errorf("parsePattern(%q) unexpected error: %v", test.path, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(tokens, test.tokens) {
+			t.Errorf("parsePattern(%q) == %+v, want %+v", test.path, tokens, test.tokens)
+		}
+	}
+
+	invalid := []string{
+		"/{}",
+		"/{name",
+		"/name}",
+		"/{na{me}",
+		"/{a}{b}",
+		"/{id:}",
+		"/{path:*}/more",
+	}
+
+	for _, path := range invalid {
+		if _, err := parsePattern(path); err == nil {
+			t.Errorf("parsePattern(%q) expected an error", path)
+		}
+	}
+}
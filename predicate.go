@@ -0,0 +1,255 @@
+package router
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Predicate restricts the requests served by a route registered with
+// HandleWhen.
+// Predicates implementing fmt.Stringer are described by the introspection
+// and compared to detect duplicated routes.
+type Predicate interface {
+	Match(r *http.Request) bool
+}
+
+// PredicateFunc adapts an ordinary function to a Predicate
+type PredicateFunc func(r *http.Request) bool
+
+// Match calls f(r)
+func (f PredicateFunc) Match(r *http.Request) bool {
+	return f(r)
+}
+
+type predicate struct {
+	desc  string
+	match func(r *http.Request) bool
+}
+
+func (p *predicate) Match(r *http.Request) bool {
+	return p.match(r)
+}
+
+func (p *predicate) String() string {
+	return p.desc
+}
+
+// HeaderEquals matches the requests whose header has the given value
+func HeaderEquals(name, value string) Predicate {
+	return &predicate{
+		desc: fmt.Sprintf("header %s == %q", http.CanonicalHeaderKey(name), value),
+		match: func(r *http.Request) bool {
+			for _, v := range r.Header.Values(name) {
+				if v == value {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// HeaderMatches matches the requests whose header matches the given regex
+func HeaderMatches(name, pattern string) Predicate {
+	re := regexp.MustCompile(pattern)
+
+	return &predicate{
+		desc: fmt.Sprintf("header %s =~ %q", http.CanonicalHeaderKey(name), pattern),
+		match: func(r *http.Request) bool {
+			for _, v := range r.Header.Values(name) {
+				if re.MatchString(v) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// QueryPresent matches the requests with the given query param
+func QueryPresent(name string) Predicate {
+	return &predicate{
+		desc: fmt.Sprintf("query %s", name),
+		match: func(r *http.Request) bool {
+			_, ok := r.URL.Query()[name]
+			return ok
+		},
+	}
+}
+
+// QueryEquals matches the requests whose query param has the given value
+func QueryEquals(name, value string) Predicate {
+	return &predicate{
+		desc: fmt.Sprintf("query %s == %q", name, value),
+		match: func(r *http.Request) bool {
+			for _, v := range r.URL.Query()[name] {
+				if v == value {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// Scheme matches the requests made with one of the given schemes
+func Scheme(schemes ...string) Predicate {
+	return &predicate{
+		desc: "scheme " + strings.Join(schemes, "|"),
+		match: func(r *http.Request) bool {
+			scheme := requestScheme(r)
+
+			for _, s := range schemes {
+				if strings.EqualFold(s, scheme) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// ContentType matches the requests whose body has one of the given media
+// types. A media type like "text/*" matches all the subtypes.
+func ContentType(mediaTypes ...string) Predicate {
+	return &predicate{
+		desc: "content-type " + strings.Join(mediaTypes, "|"),
+		match: func(r *http.Request) bool {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil {
+				return false
+			}
+
+			for _, mt := range mediaTypes {
+				mt = strings.ToLower(mt)
+
+				if mt == mediaType || (strings.HasSuffix(mt, "/*") && strings.HasPrefix(mediaType, mt[:len(mt)-1])) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// Host matches the requests made to one of the given hosts, compared
+// case-insensitively and without the port
+func Host(hosts ...string) Predicate {
+	return &predicate{
+		desc: "host " + strings.Join(hosts, "|"),
+		match: func(r *http.Request) bool {
+			host := r.Host
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+
+			for _, h := range hosts {
+				if strings.EqualFold(h, host) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+func requestScheme(r *http.Request) string {
+	switch {
+	case r.URL.Scheme != "":
+		return r.URL.Scheme
+	case r.TLS != nil:
+		return "https"
+	default:
+		return "http"
+	}
+}
+
+// HandleWhen registers a new request handler with the given path and
+// method, which only serves the requests matching all the predicates.
+// Several routes may be registered for the same path and method with
+// different predicates. The first registered route whose predicates match
+// serves the request, and a route registered with Handle serves the others.
+// If no route matches, the request is answered as if no route was
+// registered for the method.
+func (router *Router) HandleWhen(method, path string, handler http.HandlerFunc, predicates ...Predicate) *Route {
+	if len(predicates) == 0 {
+		panic("predicates must not be empty")
+	}
+
+	return router.handle(&Route{method: method, path: path, handler: handler, predicates: predicates})
+}
+
+// HandleWhen registers a new request handler with the given path and
+// method, which only serves the requests matching all the predicates.
+// See Router.HandleWhen.
+func (g *Group) HandleWhen(method, path string, handler http.HandlerFunc, predicates ...Predicate) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.HandleWhen(method, g.prefix+path, handler, predicates...)
+}
+
+// Predicates returns the predicates of the route, if registered with
+// HandleWhen
+func (route *Route) Predicates() []Predicate {
+	return route.predicates
+}
+
+// match reports whether the request matches all the predicates of the route
+func (route *Route) match(r *http.Request) bool {
+	for _, p := range route.predicates {
+		if !p.Match(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// samePredicates reports whether both routes have the same predicates.
+// Predicates which can't be described are never the same.
+func (route *Route) samePredicates(other *Route) bool {
+	if len(route.predicates) != len(other.predicates) {
+		return false
+	}
+
+	for i := range route.predicates {
+		a, ok := route.predicates[i].(fmt.Stringer)
+		if !ok {
+			return false
+		}
+
+		b, ok := other.predicates[i].(fmt.Stringer)
+		if !ok || a.String() != b.String() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// describePredicates describes the predicates of the route
+func (route *Route) describePredicates() string {
+	descs := make([]string, len(route.predicates))
+
+	for i, p := range route.predicates {
+		if s, ok := p.(fmt.Stringer); ok {
+			descs[i] = s.String()
+		} else {
+			descs[i] = fmt.Sprintf("%T", p)
+		}
+	}
+
+	return strings.Join(descs, ", ")
+}
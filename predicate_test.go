@@ -0,0 +1,120 @@
+package router
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouterHandleWhen(t *testing.T) {
+	bodyHandler := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}
+	}
+
+	r := New()
+	r.HandleWhen(http.MethodGet, "/search", bodyHandler("query"), QueryPresent("q"))
+	r.HandleWhen(http.MethodGet, "/search", bodyHandler("html"), HeaderEquals("Accept", "text/html"))
+	r.HandleWhen(http.MethodGet, "/search", bodyHandler("lang"), QueryEquals("lang", "en"), HeaderMatches("User-Agent", `^curl/`))
+	r.HandleWhen(http.MethodGet, "/secure", bodyHandler("tls"), Scheme("https"))
+	r.HandleWhen(http.MethodPost, "/upload", bodyHandler("image"), ContentType("image/*"))
+	r.HandleWhen(http.MethodPost, "/upload", bodyHandler("custom"), PredicateFunc(func(r *http.Request) bool {
+		return r.ContentLength == 0
+	}))
+	r.POST("/upload", bodyHandler("default"))
+	r.PUT("/search", bodyHandler("put"))
+	r.GET("/plain", bodyHandler("plain"))
+
+	tests := []struct {
+		method string
+		url    string
+		header http.Header
+		tls    bool
+		body   string
+		code   int
+		allow  string
+	}{
+		{http.MethodGet, "/search?q=go", nil, false, "query", http.StatusOK, ""},
+		{http.MethodGet, "/search?q=go", http.Header{"Accept": {"text/html"}}, false, "query", http.StatusOK, ""},
+		{http.MethodGet, "/search", http.Header{"Accept": {"text/html"}}, false, "html", http.StatusOK, ""},
+		{http.MethodGet, "/search?lang=en", http.Header{"User-Agent": {"curl/8.0"}}, false, "lang", http.StatusOK, ""},
+		{http.MethodGet, "/search?lang=en", nil, false, "", http.StatusMethodNotAllowed, "OPTIONS, PUT"},
+		{http.MethodGet, "/secure", nil, true, "tls", http.StatusOK, ""},
+		{http.MethodGet, "/secure", nil, false, "", http.StatusNotFound, ""},
+		{http.MethodPost, "/upload", http.Header{"Content-Type": {"image/png"}}, false, "image", http.StatusOK, ""},
+		{http.MethodPost, "/upload", http.Header{"Content-Type": {"text/plain"}}, false, "custom", http.StatusOK, ""},
+		{http.MethodGet, "/plain", nil, false, "plain", http.StatusOK, ""},
+		{http.MethodOptions, "/search", nil, false, "", http.StatusOK, "OPTIONS, PUT"},
+		{http.MethodOptions, "/search?q=1", nil, false, "", http.StatusOK, "GET, OPTIONS, PUT"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.url, nil)
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+
+		if test.tls {
+			req.TLS = &tls.ConnectionState{}
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("%s %s: response == %d %q, want %d %q", test.method, test.url, w.Code, w.Body.String(), test.code, test.body)
+		}
+
+		if allow := w.Header().Get("Allow"); allow != test.allow {
+			t.Errorf("%s %s: Allow == %q, want %q", test.method, test.url, allow, test.allow)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("data"))
+	req.Header.Set("Content-Type", "text/plain")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.String() != "default" {
+		t.Errorf("unconditional route not served: %q", w.Body.String())
+	}
+
+	recv := catchPanic(func() {
+		r.HandleWhen(http.MethodGet, "/search", bodyHandler("dup"), QueryPresent("q"))
+	})
+	if recv == nil {
+		t.Error("registering duplicated predicates did not panic")
+	} else if !strings.Contains(recv.(string), "query q") {
+		t.Errorf("unexpected panic: %v", recv)
+	}
+
+	if got := r.Routes()[0].Predicates(); len(got) != 1 {
+		t.Errorf("len(Predicates()) == %d, want 1", len(got))
+	}
+}
+
+func TestRouterHandleWhenVersion(t *testing.T) {
+	r := New()
+	r.HandleVersion(http.MethodGet, "/users", "1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v1"))
+	})
+	r.HandleWhen(http.MethodGet, "/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("csv"))
+	}, HeaderEquals("Accept", "text/csv"))
+
+	for header, want := range map[string]string{"text/csv": "csv", "application/json": "v1"} {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set("Accept", header)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Body.String() != want {
+			t.Errorf("Accept %s: body == %q, want %q", header, w.Body.String(), want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Problem is a RFC 9457 problem details object
+type Problem struct {
+	// Type is a URI reference identifying the problem type.
+	// It defaults to "about:blank".
+	Type string
+
+	// Title is a short summary of the problem type
+	Title string
+
+	// Status is the HTTP status code
+	Status int
+
+	// Detail explains this occurrence of the problem
+	Detail string
+
+	// Instance is a URI reference identifying this occurrence of the
+	// problem, the request path by default
+	Instance string
+
+	// Extensions are additional members of the problem
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON encodes the standard members first, then the extensions
+// sorted by name
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+
+	write := func(key string, value interface{}) error {
+		data := new(bytes.Buffer)
+
+		enc := json.NewEncoder(data)
+		enc.SetEscapeHTML(false)
+
+		if err := enc.Encode(value); err != nil {
+			return err
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString(strconv.Quote(key))
+		buf.WriteByte(':')
+		buf.Write(bytes.TrimSuffix(data.Bytes(), []byte("\n")))
+
+		return nil
+	}
+
+	typ := p.Type
+	if typ == "" {
+		typ = "about:blank"
+	}
+
+	write("type", typ)
+	write("title", p.Title)
+	write("status", p.Status)
+
+	if p.Detail != "" {
+		write("detail", p.Detail)
+	}
+
+	if p.Instance != "" {
+		write("instance", p.Instance)
+	}
+
+	keys := make([]string, 0, len(p.Extensions))
+	for key := range p.Extensions {
+		switch key {
+		case "type", "title", "status", "detail", "instance":
+		default:
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := write(key, p.Extensions[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// ProblemResponder renders the errors generated by the router as RFC 9457
+// problem details, in application/problem+json, or in HTML or plain text
+// depending on the Accept header of the request
+type ProblemResponder struct {
+	// TypeURI returns the type URI of the problems with the given status.
+	// If it is not set, the type is "about:blank".
+	TypeURI func(status int) string
+
+	// Extend allows to customise the problems, e.g. to add extension
+	// members, before they are written
+	Extend func(r *http.Request, p *Problem)
+}
+
+// NewProblem returns the problem with the given status and detail.
+// The title is the status text and the instance is the request path.
+func (pr *ProblemResponder) NewProblem(r *http.Request, status int, detail string) *Problem {
+	p := &Problem{
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	}
+
+	if pr.TypeURI != nil {
+		p.Type = pr.TypeURI(status)
+	}
+
+	return p
+}
+
+// Write writes the problem, in the format accepted by the request
+func (pr *ProblemResponder) Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	if pr.Extend != nil {
+		pr.Extend(r, p)
+	}
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("X-Content-Type-Options", "nosniff")
+	addVary(h, "Accept")
+
+	switch problemFormat(r) {
+	case "text/html":
+		h.Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(p.Status)
+
+		title := html.EscapeString(strconv.Itoa(p.Status) + " " + p.Title)
+		fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><title>%s</title></head><body><h1>%s</h1>", title, title)
+
+		if p.Detail != "" {
+			fmt.Fprintf(w, "<p>%s</p>", html.EscapeString(p.Detail))
+		}
+
+		fmt.Fprint(w, "</body></html>\n")
+	case "text/plain":
+		h.Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(p.Status)
+
+		fmt.Fprintf(w, "%d %s\n", p.Status, p.Title)
+
+		if p.Detail != "" {
+			fmt.Fprintln(w, p.Detail)
+		}
+	default:
+		data, err := p.MarshalJSON()
+		if err != nil {
+			data, _ = (&Problem{Type: p.Type, Title: p.Title, Status: p.Status, Detail: p.Detail, Instance: p.Instance}).MarshalJSON()
+		}
+
+		h.Set("Content-Type", "application/problem+json")
+		w.WriteHeader(p.Status)
+		w.Write(append(data, '\n'))
+	}
+}
+
+// problemFormat returns the media type of the problem accepted by the
+// request: JSON unless HTML or plain text is preferred
+func problemFormat(r *http.Request) string {
+	best, bestQ := "application/problem+json", 0.0
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+
+			if q <= 0 {
+				// Not acceptable
+				continue
+			}
+
+			var format string
+
+			switch {
+			case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+				format = "text/html"
+			case mediaType == "text/plain":
+				format = "text/plain"
+			case mediaType == "application/problem+json" || isJSONMediaType(mediaType) || mediaType == "*/*":
+				format = "application/problem+json"
+			default:
+				continue
+			}
+
+			if q > bestQ {
+				best, bestQ = format, q
+			}
+		}
+	}
+
+	return best
+}
+
+// WriteProblem answers the request with the given error status, like the
+// errors generated by the router, e.g. 413 Request Entity Too Large or
+// 429 Too Many Requests from a middleware.
+func (router *Router) WriteProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	router.writeProblem(w, r, status, detail, nil)
+}
+
+// writeProblem answers the request with an error generated by the router.
+// If Router.Problems is not set, only the status code is written.
+func (router *Router) writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string, extensions map[string]interface{}) {
+	if router.Problems == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	p := router.Problems.NewProblem(r, status, detail)
+	p.Extensions = extensions
+
+	router.Problems.Write(w, r, p)
+}
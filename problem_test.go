@@ -0,0 +1,151 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRouterProblems(t *testing.T) {
+	r := New()
+	r.Problems = &ProblemResponder{
+		TypeURI: func(status int) string {
+			return "https://errors.example.com/" + http.StatusText(status)
+		},
+		Extend: func(req *http.Request, p *Problem) {
+			if p.Extensions == nil {
+				p.Extensions = map[string]interface{}{}
+			}
+
+			p.Extensions["requestId"] = req.Header.Get("X-Request-Id")
+		},
+	}
+
+	r.GET("/users/{id}", func(w http.ResponseWriter, req *http.Request) {})
+	r.PUT("/users/{userID}", func(w http.ResponseWriter, req *http.Request) {})
+	r.GET("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		method string
+		path   string
+		want   map[string]interface{}
+	}{
+		{http.MethodGet, "/missing", map[string]interface{}{
+			"type":      "https://errors.example.com/Not Found",
+			"title":     "Not Found",
+			"status":    float64(404),
+			"instance":  "/missing",
+			"requestId": "42",
+		}},
+		{http.MethodPost, "/users/1", map[string]interface{}{
+			"type":      "https://errors.example.com/Method Not Allowed",
+			"title":     "Method Not Allowed",
+			"status":    float64(405),
+			"instance":  "/users/1",
+			"allow":     []interface{}{"GET", "OPTIONS", "PUT"},
+			"pattern":   "/users/{id}",
+			"requestId": "42",
+		}},
+		{http.MethodGet, "/panic", map[string]interface{}{
+			"type":      "https://errors.example.com/Internal Server Error",
+			"title":     "Internal Server Error",
+			"status":    float64(500),
+			"instance":  "/panic",
+			"requestId": "42",
+		}},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(test.method, test.path, nil)
+		req.Header.Set("X-Request-Id", "42")
+		r.ServeHTTP(w, req)
+
+		if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("%s %s: Content-Type == %q, want application/problem+json", test.method, test.path, ct)
+		}
+
+		if code := int(test.want["status"].(float64)); w.Code != code {
+			t.Errorf("%s %s: code == %d, want %d", test.method, test.path, w.Code, code)
+		}
+
+		got := map[string]interface{}{}
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s %s: invalid body %q: %v", test.method, test.path, w.Body.String(), err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s %s: problem == %v, want %v", test.method, test.path, got, test.want)
+		}
+	}
+}
+
+func TestRouterProblemsFormat(t *testing.T) {
+	r := New()
+	r.Problems = &ProblemResponder{}
+	r.WriteProblem(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), http.StatusTooManyRequests, "")
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "application/problem+json", `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"slow <down>","instance":"/api"}`},
+		{"application/json", "application/problem+json", `"status":429`},
+		{"text/html,application/xhtml+xml,*/*;q=0.8", "text/html; charset=utf-8", "<p>slow &lt;down&gt;</p>"},
+		{"text/plain", "text/plain; charset=utf-8", "429 Too Many Requests\nslow <down>\n"},
+		{"text/html;q=0.5, application/problem+json", "application/problem+json", `"title":"Too Many Requests"`},
+		{"image/png", "application/problem+json", `"status":429`},
+		{"text/plain;q=0, image/png", "application/problem+json", `"status":429`},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("Accept", test.accept)
+		r.WriteProblem(w, req, http.StatusTooManyRequests, "slow <down>")
+
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("Accept %q: code == %d, want %d", test.accept, w.Code, http.StatusTooManyRequests)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != test.contentType {
+			t.Errorf("Accept %q: Content-Type == %q, want %q", test.accept, ct, test.contentType)
+		}
+
+		if !strings.Contains(w.Body.String(), test.body) {
+			t.Errorf("Accept %q: body == %q, want %q", test.accept, w.Body.String(), test.body)
+		}
+	}
+}
+
+func TestRouterProblemsDisabled(t *testing.T) {
+	r := New()
+	r.GET("/users", func(w http.ResponseWriter, req *http.Request) {})
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, "/missing", nil))
+
+		if w.Code != http.StatusNotFound || w.Body.Len() != 0 {
+			t.Errorf("%s /missing: code == %d, body == %q, want an empty 404", method, w.Code, w.Body.String())
+		}
+	}
+
+	r.NotFound = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	r.Problems = &ProblemResponder{}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("NotFound handler not used: code == %d", w.Code)
+	}
+}
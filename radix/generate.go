@@ -0,0 +1,434 @@
+package radix
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"reflect"
+	"strconv"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+var _ Matcher = (*Tree)(nil)
+
+// Matcher looks up the values of the paths.
+// It's implemented by Tree, and by the static matchers generated by
+// Tree.Generate.
+type Matcher interface {
+	Find(path string) (interface{}, Params, bool)
+	FindFunc(path string, accept func(value interface{}) bool) (interface{}, Params, bool)
+	FindCaseInsensitivePath(path string, fixTrailingSlash bool, buf *bytebufferpool.ByteBuffer) bool
+}
+
+// GenerateOptions configures the matcher generated by Tree.Generate
+type GenerateOptions struct {
+	// Package is the name of the generated package, "routes" by default
+	Package string
+
+	// Type is the name of the generated matcher type, "Matcher" by default
+	Type string
+
+	// Value returns the Go expression of a value of the tree.
+	// By default, the values of basic types are generated as literals and
+	// the others are rejected.
+	Value func(value interface{}) (string, error)
+}
+
+// Generate returns the Go source of a static matcher equivalent to the
+// tree: its Find, FindFunc and FindCaseInsensitivePath methods return
+// the same values, params and trailing slash recommendations, without
+// building a tree at startup.
+// The generated type also has a Values method returning all the values.
+func (t *Tree) Generate(opts GenerateOptions) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "routes"
+	}
+
+	if opts.Type == "" {
+		opts.Type = "Matcher"
+	}
+
+	if !token.IsIdentifier(opts.Type) {
+		return nil, fmt.Errorf("invalid matcher type name '%s'", opts.Type)
+	}
+
+	if opts.Value == nil {
+		opts.Value = literalValue
+	}
+
+	g := &generator{
+		typ:     opts.Type,
+		value:   opts.Value,
+		ids:     make(map[*node]int),
+		reIndex: make(map[string]int),
+	}
+
+	g.number(t.root)
+
+	if err := g.matcher(t.root); err != nil {
+		return nil, err
+	}
+
+	var src bytes.Buffer
+
+	fmt.Fprintf(&src, "// Code generated by radix.Tree.Generate. DO NOT EDIT.\n\npackage %s\n\n", opts.Package)
+	src.WriteString("import (\n\t\"regexp\"\n\t\"strings\"\n\n\t\"github.com/pedia/router/radix\"\n\t\"github.com/valyala/bytebufferpool\"\n)\n\n")
+
+	if len(g.regexes) > 0 {
+		fmt.Fprintf(&src, "var %sRegexes = [...]*regexp.Regexp{\n", g.recv())
+
+		for _, re := range g.regexes {
+			fmt.Fprintf(&src, "\tregexp.MustCompile(%s),\n", strconv.Quote(re))
+		}
+
+		src.WriteString("}\n\n")
+	}
+
+	src.Write(g.buf.Bytes())
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated matcher: %w", err)
+	}
+
+	return out, nil
+}
+
+// literalValue returns the Go literal of a value of a basic type
+func literalValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case int, bool:
+		return fmt.Sprintf("%#v", v), nil
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return fmt.Sprintf("%T(%#v)", value, value), nil
+	}
+
+	return "", fmt.Errorf("value of type %T can't be generated, set GenerateOptions.Value", value)
+}
+
+// generator generates the functions of a static matcher, one per node and
+// lookup, mirroring the lookups of the tree
+type generator struct {
+	typ     string
+	value   func(value interface{}) (string, error)
+	ids     map[*node]int
+	nodes   []*node
+	regexes []string
+	reIndex map[string]int
+	values  []string
+	buf     bytes.Buffer
+}
+
+// number numbers the nodes in depth-first order
+func (g *generator) number(n *node) {
+	g.ids[n] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+
+	for _, child := range n.children {
+		g.number(child)
+	}
+}
+
+// recv returns the name of the package-level identifiers of the matcher
+func (g *generator) recv() string {
+	return "_" + g.typ
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// expr returns the Go expression of the value
+func (g *generator) expr(value interface{}) (string, error) {
+	expr, err := g.value(value)
+	if err != nil {
+		return "", err
+	}
+
+	g.values = append(g.values, expr)
+
+	return expr, nil
+}
+
+// regex returns the expression of the compiled regex of the param node
+func (g *generator) regex(n *node) string {
+	if n.paramRegex == nil {
+		return "nil"
+	}
+
+	pattern := n.paramRegex.String()
+
+	i, ok := g.reIndex[pattern]
+	if !ok {
+		i = len(g.regexes)
+		g.reIndex[pattern] = i
+		g.regexes = append(g.regexes, pattern)
+	}
+
+	return fmt.Sprintf("%sRegexes[%d]", g.recv(), i)
+}
+
+func (g *generator) matcher(root *node) error {
+	typ := g.typ
+
+	g.printf("// %s is a static matcher generated from a radix tree\ntype %s struct{}\n\n", typ, typ)
+
+	g.printf("// Find returns the value registered with the given path, like radix.Tree.Find\n")
+	g.printf("func (m %s) Find(path string) (interface{}, radix.Params, bool) {\n\treturn m.FindFunc(path, nil)\n}\n\n", typ)
+
+	g.printf("// FindFunc returns the value registered with the given path, like\n// radix.Tree.FindFunc\n")
+	g.printf("func (m %s) FindFunc(path string, accept func(value interface{}) bool) (interface{}, radix.Params, bool) {\n", typ)
+	g.printf("\tvar params radix.Params\n\n")
+	g.printf("\tif len(path) > %d {\n", len(root.path))
+	g.printf("\t\tif path[:%d] != %q {\n\t\t\treturn nil, nil, false\n\t\t}\n\n", len(root.path), root.path)
+	g.printf("\t\tv, tsr := m.get%d(path[%d:], &params, accept)\n", g.ids[root], len(root.path))
+	g.printf("\t\tif v == nil {\n\t\t\treturn nil, nil, tsr\n\t\t}\n\n")
+	g.printf("\t\tfor i, j := 0, len(params)-1; i < j; i, j = i+1, j-1 {\n\t\t\tparams[i], params[j] = params[j], params[i]\n\t\t}\n\n")
+	g.printf("\t\treturn v, params, false\n")
+	g.printf("\t} else if path == %q {\n", root.path)
+
+	switch {
+	case root.tsr:
+		g.printf("\t\treturn nil, nil, true\n")
+	default:
+		if root.value != nil {
+			v, err := g.expr(root.value)
+			if err != nil {
+				return err
+			}
+
+			g.printf("\t\tif accept == nil || accept(%s) {\n\t\t\treturn %s, nil, false\n\t\t}\n", v, v)
+		}
+
+		if root.wildcard != nil {
+			v, err := g.expr(root.wildcard.value)
+			if err != nil {
+				return err
+			}
+
+			g.printf("\t\tif accept == nil || accept(%s) {\n\t\t\treturn %s, radix.Params{{Key: %q}}, false\n\t\t}\n", v, v, root.wildcard.paramKey)
+		}
+	}
+
+	g.printf("\t}\n\n\treturn nil, nil, false\n}\n\n")
+
+	g.printf("// FindCaseInsensitivePath makes a case-insensitive lookup of the given\n// path, like radix.Tree.FindCaseInsensitivePath\n")
+	g.printf("func (m %s) FindCaseInsensitivePath(path string, fixTrailingSlash bool, buf *bytebufferpool.ByteBuffer) bool {\n", typ)
+	g.printf("\tfound, tsr := m.find%d(path, buf)\n\n", g.ids[root])
+	g.printf("\tif !found || (tsr && !fixTrailingSlash) {\n\t\tbuf.Reset()\n\n\t\treturn false\n\t}\n\n\treturn true\n}\n\n")
+
+	g.helpers()
+
+	for _, n := range g.nodes {
+		if err := g.get(n); err != nil {
+			return err
+		}
+
+		if n.nType != param {
+			g.find(n)
+		}
+
+		g.findFromChild(n)
+	}
+
+	g.printf("// Values returns the values of the matcher\n")
+	g.printf("func (m %s) Values() []interface{} {\n\treturn []interface{}{\n", typ)
+
+	seen := make(map[string]bool)
+	for _, v := range g.values {
+		if !seen[v] {
+			seen[v] = true
+			g.printf("\t\t%s,\n", v)
+		}
+	}
+
+	g.printf("\t}\n}\n")
+
+	return nil
+}
+
+// helpers generates the helpers of the matcher, as methods so several
+// matchers can be generated in the same package
+func (g *generator) helpers() {
+	g.printf("// paramEnd returns the end of the param at the beginning of the path,\n// and its values, or -1 if it doesn't match its regex\n")
+	g.printf("func (m %s) paramEnd(path string, re *regexp.Regexp) (int, []string) {\n", g.typ)
+	g.printf("\tend := strings.IndexByte(path, '/')\n\tif end == -1 {\n\t\tend = len(path)\n\t}\n\n")
+	g.printf("\tif re == nil {\n\t\treturn end, []string{path[:end]}\n\t}\n\n")
+	g.printf("\tindex := re.FindStringSubmatchIndex(path[:end])\n")
+	g.printf("\tif len(index) == 0 || index[0] != 0 {\n\t\treturn -1, nil\n\t}\n\n")
+	g.printf("\tvalues := make([]string, 0, len(index)/2-1)\n")
+	g.printf("\tfor i := 2; i < len(index); i += 2 {\n\t\tvalues = append(values, path[index[i]:index[i+1]])\n\t}\n\n")
+	g.printf("\treturn index[1], values\n}\n\n")
+}
+
+// get generates the lookup of the path in the children of the node, like
+// node.getFromChild
+func (g *generator) get(n *node) error {
+	g.printf("func (m %s) get%d(path string, params *radix.Params, accept func(interface{}) bool) (interface{}, bool) {\n", g.typ, g.ids[n])
+
+	for _, child := range n.children {
+		id := g.ids[child]
+
+		switch child.nType {
+		case static:
+			g.printf("\tif path[0] == %q {\n", child.path[0])
+			g.printf("\t\tif len(path) > %d {\n", len(child.path))
+
+			// The first byte is already compared
+			call := fmt.Sprintf("if v, tsr := m.get%d(path[%d:], params, accept); v != nil || tsr {\n\treturn v, tsr\n}\n", id, len(child.path))
+			if len(child.path) > 1 {
+				call = fmt.Sprintf("if path[:%d] == %q {\n%s}\n", len(child.path), child.path, call)
+			}
+
+			g.printf("%s", call)
+			g.printf("\t\t} else if path == %q {\n", child.path)
+
+			if child.tsr {
+				g.printf("\t\t\treturn nil, true\n")
+			} else {
+				if child.value != nil {
+					v, err := g.expr(child.value)
+					if err != nil {
+						return err
+					}
+
+					g.printf("\t\t\tif accept == nil || accept(%s) {\n\t\t\t\treturn %s, false\n\t\t\t}\n", v, v)
+				}
+
+				if child.wildcard != nil {
+					v, err := g.expr(child.wildcard.value)
+					if err != nil {
+						return err
+					}
+
+					g.printf("\t\t\tif accept == nil || accept(%s) {\n", v)
+					g.printf("\t\t\t\t*params = append(*params, radix.Param{Key: %q})\n\n", child.wildcard.paramKey)
+					g.printf("\t\t\t\treturn %s, false\n\t\t\t}\n", v)
+				}
+
+				if child.value == nil && child.wildcard == nil {
+					g.printf("\t\t\treturn nil, false\n")
+				}
+			}
+
+			g.printf("\t\t}\n\t}\n\n")
+
+		case param:
+			g.printf("\tif end, values := m.paramEnd(path, %s); end != -1 {\n", g.regex(child))
+			g.printf("\t\tif len(path) > end {\n")
+			g.printf("\t\t\tv, tsr := m.get%d(path[end:], params, accept)\n", id)
+			g.printf("\t\t\tif tsr {\n\t\t\t\treturn nil, true\n\t\t\t} else if v != nil {\n")
+			g.appendParams(child, "\t\t\t\t")
+			g.printf("\n\t\t\t\treturn v, false\n\t\t\t}\n")
+			g.printf("\t\t} else {\n")
+
+			switch {
+			case child.tsr:
+				g.printf("\t\t\treturn nil, true\n")
+			case child.value != nil:
+				v, err := g.expr(child.value)
+				if err != nil {
+					return err
+				}
+
+				g.printf("\t\t\tif accept == nil || accept(%s) {\n", v)
+				g.appendParams(child, "\t\t\t\t")
+				g.printf("\n\t\t\t\treturn %s, false\n\t\t\t}\n", v)
+			}
+
+			g.printf("\t\t}\n\t}\n\n")
+		}
+	}
+
+	if n.wildcard != nil {
+		v, err := g.expr(n.wildcard.value)
+		if err != nil {
+			return err
+		}
+
+		g.printf("\tif accept == nil || accept(%s) {\n", v)
+		g.printf("\t\t*params = append(*params, radix.Param{Key: %q, Value: path})\n\n", n.wildcard.paramKey)
+		g.printf("\t\treturn %s, false\n\t}\n\n", v)
+	}
+
+	g.printf("\treturn nil, false\n}\n\n")
+
+	return nil
+}
+
+// appendParams generates the appending of the values of the params of the
+// node, in reverse order like node.appendParams
+func (g *generator) appendParams(n *node, indent string) {
+	for i := len(n.paramKeys) - 1; i >= 0; i-- {
+		g.printf("%s*params = append(*params, radix.Param{Key: %q, Value: values[%d]})\n", indent, n.paramKeys[i], i)
+	}
+}
+
+// find generates the case-insensitive lookup of the path in the node, like
+// node.find
+func (g *generator) find(n *node) {
+	id := g.ids[n]
+
+	g.printf("func (m %s) find%d(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {\n", g.typ, id)
+	g.printf("\tif len(path) > %d {\n", len(n.path))
+	g.printf("\t\tif !strings.EqualFold(path[:%d], %q) {\n\t\t\treturn false, false\n\t\t}\n\n", len(n.path), n.path)
+	g.printf("\t\tbuf.WriteString(%q)\n\n", n.path)
+	g.printf("\t\tif found, tsr := m.findFromChild%d(path[%d:], buf); found {\n\t\t\treturn found, tsr\n\t\t}\n\n", id, len(n.path))
+	g.printf("\t\tbuf.B = buf.B[:len(buf.B)-%d]\n", len(n.path))
+	g.printf("\t} else if strings.EqualFold(path, %q) {\n", n.path)
+
+	switch {
+	case n.tsr && n.path == "/":
+		g.printf("\t\treturn true, true\n")
+	case n.tsr:
+		g.printf("\t\tbuf.WriteString(%q)\n\n\t\treturn true, true\n", n.path+"/")
+	case n.value != nil:
+		g.printf("\t\tbuf.WriteString(%q)\n\n\t\treturn true, false\n", n.path)
+	}
+
+	g.printf("\t}\n\n\treturn false, false\n}\n\n")
+}
+
+// findFromChild generates the case-insensitive lookup of the path in the
+// children of the node, like node.findFromChild
+func (g *generator) findFromChild(n *node) {
+	g.printf("func (m %s) findFromChild%d(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {\n", g.typ, g.ids[n])
+
+	for _, child := range n.children {
+		id := g.ids[child]
+
+		switch child.nType {
+		case static:
+			g.printf("\tif found, tsr := m.find%d(path, buf); found {\n\t\treturn found, tsr\n\t}\n\n", id)
+		case param:
+			g.printf("\tif end, _ := m.paramEnd(path, %s); end != -1 {\n", g.regex(child))
+			g.printf("\t\tbuf.WriteString(path[:end])\n\n")
+			g.printf("\t\tif len(path) > end {\n")
+			g.printf("\t\t\tif found, tsr := m.findFromChild%d(path[end:], buf); found {\n\t\t\t\treturn found, tsr\n\t\t\t}\n", id)
+
+			switch {
+			case child.tsr:
+				g.printf("\t\t} else {\n\t\t\tbuf.WriteByte('/')\n\n\t\t\treturn true, true\n")
+			case child.value != nil:
+				g.printf("\t\t} else {\n\t\t\treturn true, false\n")
+			}
+
+			g.printf("\t\t}\n\n\t\tbuf.B = buf.B[:len(buf.B)-end]\n\t}\n\n")
+		}
+	}
+
+	if n.wildcard != nil {
+		g.printf("\tbuf.WriteString(path)\n\n\treturn true, false\n}\n\n")
+	} else {
+		g.printf("\treturn false, false\n}\n\n")
+	}
+}
@@ -0,0 +1,51 @@
+package radix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTreeGenerate(t *testing.T) {
+	tree := New()
+	tree.Insert("/users/{id:[0-9]+}", 1)
+	tree.Insert("/files/{path:*}", int64(2))
+
+	src, err := tree.Generate(GenerateOptions{Package: "routes", Type: "Routes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"package routes\n",
+		"type Routes struct{}",
+		`regexp.MustCompile("([0-9]+)")`,
+		"return 1, false",
+		"int64(2)",
+		"func (m Routes) Values() []interface{}",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated matcher doesn't contain %q", want)
+		}
+	}
+
+	tree.Insert("/data", struct{}{})
+
+	if _, err := tree.Generate(GenerateOptions{}); err == nil {
+		t.Error("generating a value of an unsupported type didn't fail")
+	}
+
+	if _, err := tree.Generate(GenerateOptions{Type: "not valid"}); err == nil {
+		t.Error("generating an invalid type name didn't fail")
+	}
+
+	src, err = tree.Generate(GenerateOptions{Value: func(v interface{}) (string, error) {
+		return `"value"`, nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(src), "type Matcher struct{}") {
+		t.Error("the default type name isn't Matcher")
+	}
+}
@@ -1,7 +1,6 @@
 package radix
 
 import (
-	"net/http"
 	"sort"
 	"strings"
 
@@ -37,7 +36,7 @@ func (n node) clone() *node {
 	cloneNode.nType = n.nType
 	cloneNode.path = n.path
 	cloneNode.tsr = n.tsr
-	cloneNode.handler = n.handler
+	cloneNode.value = n.value
 
 	if len(n.children) > 0 {
 		cloneNode.children = make([]*node, len(n.children))
@@ -51,7 +50,7 @@ func (n node) clone() *node {
 		cloneNode.wildcard = &nodeWildcard{
 			path:     n.wildcard.path,
 			paramKey: n.wildcard.paramKey,
-			handler:  n.wildcard.handler,
+			value:    n.wildcard.value,
 		}
 	}
 
@@ -73,7 +72,7 @@ func (n *node) split(i int) {
 	cloneChild.paramRegex = nil
 
 	n.path = n.path[:i]
-	n.handler = nil
+	n.value = nil
 	n.tsr = false
 	n.wildcard = nil
 	n.children = append(n.children[:0], cloneChild)
@@ -104,12 +103,12 @@ func (n *node) findEndIndexAndValues(path string) (int, []string) {
 	return end, values
 }
 
-func (n *node) setHandler(handler http.HandlerFunc, fullPath string) (*node, error) {
-	if n.handler != nil || n.tsr {
+func (n *node) setValue(value interface{}, fullPath string) (*node, error) {
+	if n.value != nil || n.tsr {
 		return n, newRadixError(errSetHandler, fullPath)
 	}
 
-	n.handler = handler
+	n.value = value
 	foundTSR := false
 
 	// Set TSR in method
@@ -140,7 +139,7 @@ func (n *node) setHandler(handler http.HandlerFunc, fullPath string) (*node, err
 	return n, nil
 }
 
-func (n *node) insert(path, fullPath string, handler http.HandlerFunc) (*node, error) {
+func (n *node) insert(path, fullPath string, value interface{}) (*node, error) {
 	end := segmentEndIndex(path, true)
 	child := newNode(path)
 
@@ -156,7 +155,7 @@ func (n *node) insert(path, fullPath string, handler http.HandlerFunc) (*node, e
 		if wp.start > 0 {
 			n.children = append(n.children, child)
 
-			return child.insert(path[j:], fullPath, handler)
+			return child.insert(path[j:], fullPath, value)
 		}
 
 		switch wp.pType {
@@ -191,7 +190,7 @@ func (n *node) insert(path, fullPath string, handler http.HandlerFunc) (*node, e
 			n.wildcard = &nodeWildcard{
 				path:     wp.path,
 				paramKey: wp.keys[0],
-				handler:  handler,
+				value:    value,
 			}
 
 			return n, nil
@@ -202,11 +201,11 @@ func (n *node) insert(path, fullPath string, handler http.HandlerFunc) (*node, e
 		if len(path) > 0 {
 			n.children = append(n.children, child)
 
-			return child.insert(path, fullPath, handler)
+			return child.insert(path, fullPath, value)
 		}
 	}
 
-	child.handler = handler
+	child.value = value
 	n.children = append(n.children, child)
 
 	if child.path == "/" {
@@ -224,10 +223,10 @@ func (n *node) insert(path, fullPath string, handler http.HandlerFunc) (*node, e
 	return child, nil
 }
 
-// add adds the handler to node for the given path
-func (n *node) add(path, fullPath string, handler http.HandlerFunc) (*node, error) {
+// add adds the value to node for the given path
+func (n *node) add(path, fullPath string, value interface{}) (*node, error) {
 	if len(path) == 0 {
-		return n.setHandler(handler, fullPath)
+		return n.setValue(value, fullPath)
 	}
 
 	for _, child := range n.children {
@@ -243,15 +242,15 @@ func (n *node) add(path, fullPath string, handler http.HandlerFunc) (*node, erro
 			}
 
 			if len(path) > i {
-				return child.add(path[i:], fullPath, handler)
+				return child.add(path[i:], fullPath, value)
 			}
 		case param:
 			wp := findWildPath(path, fullPath)
 
 			isParam := wp.start == 0 && wp.pType == param
-			hasHandler := child.handler != nil || handler == nil
+			hasValue := child.value != nil || value == nil
 
-			if len(path) == wp.end && isParam && hasHandler {
+			if len(path) == wp.end && isParam && hasValue {
 				// The current segment is a param and it's duplicated
 				if child.path == path {
 					return child, newRadixError(errSetHandler, fullPath)
@@ -262,10 +261,10 @@ func (n *node) add(path, fullPath string, handler http.HandlerFunc) (*node, erro
 
 			if len(path) > i {
 				if child.path == wp.path {
-					return child.add(path[i:], fullPath, handler)
+					return child.add(path[i:], fullPath, value)
 				}
 
-				return n.insert(path, fullPath, handler)
+				return n.insert(path, fullPath, value)
 			}
 		}
 
@@ -273,13 +272,13 @@ func (n *node) add(path, fullPath string, handler http.HandlerFunc) (*node, erro
 			n.tsr = true
 		}
 
-		return child.setHandler(handler, fullPath)
+		return child.setValue(value, fullPath)
 	}
 
-	return n.insert(path, fullPath, handler)
+	return n.insert(path, fullPath, value)
 }
 
-func (n *node) getFromChild(path string, r *http.Request) (http.HandlerFunc, bool) {
+func (n *node) getFromChild(path string, params *Params, accept func(interface{}) bool) (interface{}, bool) {
 	for _, child := range n.children {
 		switch child.nType {
 		case static:
@@ -295,27 +294,23 @@ func (n *node) getFromChild(path string, r *http.Request) (http.HandlerFunc, boo
 					continue
 				}
 
-				h, tsr := child.getFromChild(path[len(child.path):], r)
-				if h != nil || tsr {
-					return h, tsr
+				v, tsr := child.getFromChild(path[len(child.path):], params, accept)
+				if v != nil || tsr {
+					return v, tsr
 				}
 			} else if path == child.path {
 				switch {
 				case child.tsr:
 					return nil, true
-				case child.handler != nil:
-					return child.handler, false
-				case child.wildcard != nil:
-					f := child.wildcard.handler
-					if r != nil {
-						old := f
-						f = func(w http.ResponseWriter, r *http.Request) {
-							// ctx.SetUserValue(child.wildcard.paramKey, "")
-							r = AddRequestValue(r, child.wildcard.paramKey, "")
-							old(w, r)
-						}
-					}
-					return f, false
+				case accepts(accept, child.value):
+					return child.value, false
+				case child.wildcard != nil && accepts(accept, child.wildcard.value):
+					*params = append(*params, Param{Key: child.wildcard.paramKey})
+
+					return child.wildcard.value, false
+				case child.value != nil || child.wildcard != nil:
+					// Not accepted, try the other children
+					continue
 				}
 
 				return nil, false
@@ -323,7 +318,7 @@ func (n *node) getFromChild(path string, r *http.Request) (http.HandlerFunc, boo
 
 		case param:
 			end := segmentEndIndex(path, false)
-			values := []string{gstrings.Copy(path[:end])}
+			values := []string{path[:end]}
 
 			if child.paramRegex != nil {
 				end, values = child.findEndIndexAndValues(path[:end])
@@ -333,46 +328,27 @@ func (n *node) getFromChild(path string, r *http.Request) (http.HandlerFunc, boo
 			}
 
 			if len(path) > end {
-				h, tsr := child.getFromChild(path[end:], r)
+				v, tsr := child.getFromChild(path[end:], params, accept)
 				if tsr {
 					return nil, tsr
-				} else if h != nil {
-					if r != nil {
-						// for i, key := range child.paramKeys {
-						// 	ctx.SetUserValue(key, values[i])
-						// }
-						old := h
-						h = func(w http.ResponseWriter, r *http.Request) {
-							for i, key := range child.paramKeys {
-								r = AddRequestValue(r, key, values[i])
-							}
-							old(w, r)
-						}
-					}
-
-					return h, false
+				} else if v != nil {
+					child.appendParams(params, values)
+
+					return v, false
 				}
 
 			} else if len(path) == end {
-				f := child.handler
 				switch {
 				case child.tsr:
 					return nil, true
-				case child.handler == nil:
+				case !accepts(accept, child.value):
 					// try another child
 					continue
-				case r != nil:
-					old := f
-					f = func(w http.ResponseWriter, r *http.Request) {
-						for i, key := range child.paramKeys {
-							// 	ctx.SetUserValue(key, values[i])
-							r = AddRequestValue(r, key, values[i])
-						}
-						old(w, r)
-					}
 				}
 
-				return f, false
+				child.appendParams(params, values)
+
+				return child.value, false
 			}
 
 		default:
@@ -380,23 +356,28 @@ func (n *node) getFromChild(path string, r *http.Request) (http.HandlerFunc, boo
 		}
 	}
 
-	if n.wildcard != nil {
-		f := n.wildcard.handler
-		if r != nil {
-			old := f
-			f = func(w http.ResponseWriter, r *http.Request) {
-				// ctx.SetUserValue(n.wildcard.paramKey, gstrings.Copy(path))
-				r = AddRequestValue(r, n.wildcard.paramKey, gstrings.Copy(path))
-				old(w, r)
-			}
+	if n.wildcard != nil && accepts(accept, n.wildcard.value) {
+		*params = append(*params, Param{Key: n.wildcard.paramKey, Value: path})
 
-		}
-		return f, false
+		return n.wildcard.value, false
 	}
 
 	return nil, false
 }
 
+// accepts reports whether the value is set and accepted
+func accepts(accept func(interface{}) bool, value interface{}) bool {
+	return value != nil && (accept == nil || accept(value))
+}
+
+// appendParams appends the values of the params of the node, in reverse
+// order since the params are collected from the end of the path
+func (n *node) appendParams(params *Params, values []string) {
+	for i := len(n.paramKeys) - 1; i >= 0; i-- {
+		*params = append(*params, Param{Key: n.paramKeys[i], Value: values[i]})
+	}
+}
+
 func (n *node) find(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
 	if len(path) > len(n.path) {
 		if !strings.EqualFold(path[:len(n.path)], n.path) {
@@ -426,7 +407,7 @@ func (n *node) find(path string, buf *bytebufferpool.ByteBuffer) (bool, bool) {
 			return true, true
 		}
 
-		if n.handler != nil {
+		if n.value != nil {
 			return true, false
 		} else {
 			bufferRemoveString(buf, n.path)
@@ -470,7 +451,7 @@ func (n *node) findFromChild(path string, buf *bytebufferpool.ByteBuffer) (bool,
 					return true, true
 				}
 
-				if child.handler != nil {
+				if child.value != nil {
 					return true, false
 				}
 			}
@@ -21,10 +21,22 @@ func New() *Tree {
 //
 // WARNING: Not concurrency-safe!
 func (t *Tree) Add(path string, handler http.HandlerFunc) {
+	if handler == nil {
+		panic("nil handler")
+	}
+
+	t.Insert(path, handler)
+}
+
+// Insert adds a node with the given value to the path.
+// The value is returned by Find.
+//
+// WARNING: Not concurrency-safe!
+func (t *Tree) Insert(path string, value interface{}) {
 	if !strings.HasPrefix(path, "/") {
 		panicf("path must begin with '/' in path '%s'", path)
-	} else if handler == nil {
-		panic("nil handler")
+	} else if value == nil {
+		panic("nil value")
 	}
 
 	fullPath := path
@@ -38,17 +50,17 @@ func (t *Tree) Add(path string, handler http.HandlerFunc) {
 		path = path[i:]
 	}
 
-	n, err := t.root.add(path, fullPath, handler)
+	n, err := t.root.add(path, fullPath, value)
 	if err != nil {
 		var radixErr radixError
 
 		if errors.As(err, &radixErr) && t.Mutable && !n.tsr {
 			switch radixErr.msg {
 			case errSetHandler:
-				n.handler = handler
+				n.value = value
 				return
 			case errSetWildcardHandler:
-				n.wildcard.handler = handler
+				n.wildcard.value = value
 				return
 			}
 		}
@@ -71,37 +83,69 @@ func (t *Tree) Add(path string, handler http.HandlerFunc) {
 // made if a handle exists with an extra (without the) trailing slash for the
 // given path.
 func (t *Tree) Get(path string, r *http.Request) (http.HandlerFunc, bool) {
+	v, params, tsr := t.Find(path)
+	if v == nil {
+		return nil, tsr
+	}
+
+	h := v.(http.HandlerFunc)
+	if r == nil || len(params) == 0 {
+		return h, false
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range params {
+			r = AddRequestValue(r, p.Key, p.Value)
+		}
+
+		h(w, r)
+	}, false
+}
+
+// Find returns the value registered with the given path (key), and the
+// values of its params.
+// If no value can be found, a TSR (trailing slash redirect) recommendation
+// is made if a value exists with an extra (without the) trailing slash for
+// the given path.
+func (t *Tree) Find(path string) (interface{}, Params, bool) {
+	return t.FindFunc(path, nil)
+}
+
+// FindFunc returns the value registered with the given path (key) like
+// Find does, skipping the values which are not accepted, so a less specific
+// path can match.
+func (t *Tree) FindFunc(path string, accept func(value interface{}) bool) (interface{}, Params, bool) {
+	var params Params
+
 	if len(path) > len(t.root.path) {
 		if path[:len(t.root.path)] != t.root.path {
-			return nil, false
+			return nil, nil, false
+		}
+
+		v, tsr := t.root.getFromChild(path[len(t.root.path):], &params, accept)
+		if v == nil {
+			return nil, nil, tsr
 		}
 
-		path = path[len(t.root.path):]
+		// The params are collected from the end of the path
+		for i, j := 0, len(params)-1; i < j; i, j = i+1, j-1 {
+			params[i], params[j] = params[j], params[i]
+		}
 
-		return t.root.getFromChild(path, r)
+		return v, params, false
 
 	} else if path == t.root.path {
 		switch {
 		case t.root.tsr:
-			return nil, true
-		case t.root.handler != nil:
-			return t.root.handler, false
-		case t.root.wildcard != nil:
-			f := t.root.wildcard.handler
-			if r != nil {
-				old := f
-				f = func(w http.ResponseWriter, r *http.Request) {
-					// r.SetUserValue(t.root.wildcard.paramKey, "")
-					r = AddRequestValue(r, t.root.wildcard.paramKey, "")
-					old(w, r)
-				}
-			}
-
-			return f, false
+			return nil, nil, true
+		case accepts(accept, t.root.value):
+			return t.root.value, nil, false
+		case t.root.wildcard != nil && accepts(accept, t.root.wildcard.value):
+			return t.root.wildcard.value, Params{{Key: t.root.wildcard.paramKey}}, false
 		}
 	}
 
-	return nil, false
+	return nil, nil, false
 }
 
 // FindCaseInsensitivePath makes a case-insensitive lookup of the given path
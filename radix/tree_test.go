@@ -412,3 +412,32 @@ func Benchmark_FindCaseInsensitivePath(b *testing.B) {
 		buf.Reset()
 	}
 }
+
+func TestTreeFindFunc(t *testing.T) {
+	tree := New()
+	tree.Insert("/files/new", "new")
+	tree.Insert("/files/{path:*}", "files")
+	tree.Insert("/users/{id}/{tab}", "user")
+
+	value, params, tsr := tree.Find("/users/7/posts")
+	if value != "user" || tsr || !reflect.DeepEqual(params, Params{{"id", "7"}, {"tab", "posts"}}) {
+		t.Errorf("Find() == %v, %v, %v", value, params, tsr)
+	}
+
+	value, params, _ = tree.Find("/files/new")
+	if value != "new" || len(params) != 0 {
+		t.Errorf("Find() == %v, %v", value, params)
+	}
+
+	skipNew := func(v interface{}) bool { return v != "new" }
+
+	value, params, _ = tree.FindFunc("/files/new", skipNew)
+	if value != "files" || !reflect.DeepEqual(params, Params{{"path", "new"}}) {
+		t.Errorf("FindFunc() == %v, %v", value, params)
+	}
+
+	value, _, _ = tree.FindFunc("/users/7/posts", func(v interface{}) bool { return false })
+	if value != nil {
+		t.Errorf("FindFunc() == %v, want nil", value)
+	}
+}
@@ -1,7 +1,6 @@
 package radix
 
 import (
-	"net/http"
 	"regexp"
 )
 
@@ -10,7 +9,7 @@ type nodeType uint8
 type nodeWildcard struct {
 	path     string
 	paramKey string
-	handler  http.HandlerFunc
+	value    interface{}
 }
 
 type node struct {
@@ -18,7 +17,7 @@ type node struct {
 
 	path         string
 	tsr          bool
-	handler      http.HandlerFunc
+	value        interface{}
 	hasWildChild bool
 	children     []*node
 	wildcard     *nodeWildcard
@@ -38,6 +37,27 @@ type wildPath struct {
 	regex   *regexp.Regexp
 }
 
+// Param is a param of the path matched by Tree.Find
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params are the params of the path matched by Tree.Find, in path order
+type Params []Param
+
+// ByName returns the value of the first param with the given name, or an
+// empty string
+func (ps Params) ByName(name string) string {
+	for _, p := range ps {
+		if p.Key == name {
+			return p.Value
+		}
+	}
+
+	return ""
+}
+
 // Tree is a routes storage
 type Tree struct {
 	root *node
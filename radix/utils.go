@@ -176,6 +176,13 @@ type _paramCtxKey struct{}
 
 var paramCtxKey = _paramCtxKey{}
 
+// WithRequestValues returns a shallow copy of the request storing its
+// user values in the given map, so they can be read after the request is
+// served
+func WithRequestValues(r *http.Request, values map[string]string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), paramCtxKey, values))
+}
+
 func AddRequestValue(r *http.Request, key, val string) *http.Request {
 	m := r.Context().Value(paramCtxKey)
 	if m == nil {
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// TrailingSlashMode defines how a route is served when it's requested with
+// an extra or a missing trailing slash
+type TrailingSlashMode int
+
+const (
+	// TrailingSlashRedirect redirects the request to the path of the route,
+	// if Router.RedirectTrailingSlash is enabled
+	TrailingSlashRedirect TrailingSlashMode = iota
+
+	// TrailingSlashStrict only serves the path of the route
+	TrailingSlashStrict
+
+	// TrailingSlashLoose serves the path of the route with and without the
+	// trailing slash
+	TrailingSlashLoose
+)
+
+// RedirectPolicy configures the redirects made by the router to fix the
+// trailing slash or the path of the requests.
+// It's applied according to the route the request is redirected to.
+type RedirectPolicy struct {
+	// Disables the redirects
+	Disabled bool
+
+	// Status codes of the redirects by request method.
+	// By default, GET requests are redirected with 301 Moved Permanently
+	// and the others with 308 Permanent Redirect.
+	Codes map[string]int
+
+	// How requests with an extra or a missing trailing slash are served
+	TrailingSlash TrailingSlashMode
+
+	// If enabled, the Location header is an absolute URL, built from the
+	// scheme and host of the request
+	Absolute bool
+
+	// If enabled, the scheme and host of the absolute URLs are read from
+	// the Forwarded or X-Forwarded-Proto and X-Forwarded-Host headers.
+	// Only enable it behind a proxy setting them.
+	TrustForwarded bool
+}
+
+var defaultRedirectPolicy = &RedirectPolicy{}
+
+// code returns the status code of the redirects of the requests with the
+// given method
+func (policy *RedirectPolicy) code(method string) int {
+	if code, ok := policy.Codes[method]; ok {
+		return code
+	}
+
+	if method == http.MethodGet {
+		return http.StatusMovedPermanently
+	}
+
+	return http.StatusPermanentRedirect
+}
+
+// RedirectPolicy overrides Router.RedirectPolicy for the routes of the group
+// and its sub-groups.
+// It must be set before registering the routes.
+func (g *Group) RedirectPolicy(policy *RedirectPolicy) *Group {
+	g.redirect = policy
+
+	return g
+}
+
+// RedirectPolicy overrides the redirect policy of the router and the groups
+// for the route
+func (route *Route) RedirectPolicy(policy *RedirectPolicy) *Route {
+	route.redirect = policy
+
+	return route
+}
+
+// redirectPolicy returns the policy of the first route of the endpoint
+// overriding it, or the policy of the router
+func (ep *endpoint) redirectPolicy() *RedirectPolicy {
+	for _, route := range ep.routes {
+		if route.redirect != nil {
+			return route.redirect
+		}
+	}
+
+	if ep.router.RedirectPolicy == nil {
+		return defaultRedirectPolicy
+	}
+
+	return ep.router.RedirectPolicy
+}
+
+// redirect redirects the request to the given path of the router
+func (router *Router) redirect(w http.ResponseWriter, r *http.Request, policy *RedirectPolicy, path string) {
+	uri := bytebufferpool.Get()
+	defer bytebufferpool.Put(uri)
+
+	if policy.Absolute {
+		scheme, host := requestScheme(r), r.Host
+		if policy.TrustForwarded {
+			scheme, host = forwardedSchemeHost(r, scheme, host)
+		}
+
+		uri.WriteString(scheme + "://" + host)
+	}
+
+	uri.WriteString(router.locationPath(path))
+
+	if queryBuf := r.URL.RawQuery; len(queryBuf) > 0 {
+		uri.WriteByte(questionMark)
+		uri.WriteString(queryBuf)
+	}
+
+	http.Redirect(w, r, uri.String(), policy.code(r.Method))
+}
+
+// forwardedSchemeHost returns the scheme and host set by the proxy, from the
+// first element of the Forwarded header or from the X-Forwarded-Proto and
+// X-Forwarded-Host headers
+func forwardedSchemeHost(r *http.Request, scheme, host string) (string, string) {
+	if forwarded := r.Header.Get("Forwarded"); forwarded != "" {
+		first := strings.SplitN(forwarded, ",", 2)[0]
+
+		for _, pair := range strings.Split(first, ";") {
+			pair = strings.TrimSpace(pair)
+
+			i := strings.IndexByte(pair, '=')
+			if i == -1 {
+				continue
+			}
+
+			k, v := pair[:i], strings.Trim(pair[i+1:], `"`)
+
+			switch strings.ToLower(k) {
+			case "proto":
+				scheme = v
+			case "host":
+				host = v
+			}
+		}
+
+		return scheme, host
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.SplitN(proto, ",", 2)[0])
+	}
+
+	if fwdHost := r.Header.Get("X-Forwarded-Host"); fwdHost != "" {
+		host = strings.TrimSpace(strings.SplitN(fwdHost, ",", 2)[0])
+	}
+
+	return scheme, host
+}
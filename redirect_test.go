@@ -0,0 +1,88 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterRedirectPolicy(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}
+
+	r := New()
+	r.RedirectPolicy = &RedirectPolicy{
+		Codes:          map[string]int{http.MethodGet: http.StatusFound, http.MethodPost: http.StatusTemporaryRedirect},
+		Absolute:       true,
+		TrustForwarded: true,
+	}
+	r.GET("/users", handler)
+	r.POST("/users", handler)
+	r.HandleRoute(http.MethodGet, "/loose", handler).RedirectPolicy(&RedirectPolicy{TrailingSlash: TrailingSlashLoose})
+	r.HandleRoute(http.MethodGet, "/strict/", handler).RedirectPolicy(&RedirectPolicy{TrailingSlash: TrailingSlashStrict})
+
+	api := r.Group("/api").RedirectPolicy(&RedirectPolicy{Disabled: true})
+	api.GET("/items", handler)
+	api.Group("/v2").RedirectPolicy(&RedirectPolicy{}).GET("/items", handler)
+
+	tests := []struct {
+		method   string
+		path     string
+		header   http.Header
+		code     int
+		location string
+	}{
+		{http.MethodGet, "/users/", nil, http.StatusFound, "http://example.com/users"},
+		{http.MethodPost, "/users/?a=1", nil, http.StatusTemporaryRedirect, "http://example.com/users?a=1"},
+		{http.MethodGet, "/USERS", http.Header{"X-Forwarded-Proto": {"https"}, "X-Forwarded-Host": {"api.example.org"}}, http.StatusFound, "https://api.example.org/users"},
+		{http.MethodGet, "/users/", http.Header{"Forwarded": {`for=1.2.3.4;proto=https;host="proxy.example.org", for=5.6.7.8`}}, http.StatusFound, "https://proxy.example.org/users"},
+		{http.MethodGet, "/loose", nil, http.StatusOK, ""},
+		{http.MethodGet, "/loose/", nil, http.StatusOK, ""},
+		{http.MethodGet, "/strict", nil, http.StatusNotFound, ""},
+		{http.MethodGet, "/api/items/", nil, http.StatusNotFound, ""},
+		{http.MethodGet, "/API/ITEMS", nil, http.StatusNotFound, ""},
+		{http.MethodGet, "/api/v2/items/", nil, http.StatusMovedPermanently, "/api/v2/items"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Header().Get("Location") != test.location {
+			t.Errorf("%s %s: response == %d %q, want %d %q", test.method, test.path, w.Code, w.Header().Get("Location"), test.code, test.location)
+		}
+	}
+}
+
+func TestGroupRedirectPolicyScope(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.Group("/api").RedirectPolicy(&RedirectPolicy{Disabled: true}).GET("/users", handler)
+	r.Group("/api").GET("/items", handler)
+	r.GET("/api/orders", handler)
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/api/users/", http.StatusNotFound},
+		{"/api/items/", http.StatusMovedPermanently},
+		{"/api/orders/", http.StatusMovedPermanently},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if w.Code != test.code {
+			t.Errorf("GET %s: status == %d, want %d", test.path, w.Code, test.code)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Routes returns all registered routes in registration order
+func (router *Router) Routes() []*Route {
+	routes := make([]*Route, len(router.routes))
+	copy(routes, router.routes)
+
+	return routes
+}
+
+// NamedRoute returns the route registered with the given name, or nil
+func (router *Router) NamedRoute(name string) *Route {
+	return router.namedRoutes[name]
+}
+
+// Match returns the route serving the request and the values of its path
+// params, or nil if no route serves it.
+// Redirects, case-insensitive matches and path suffixes are not followed.
+func (router *Router) Match(r *http.Request) (*Route, map[string]string) {
+	methodIndex := router.methodIndexOf(r.Method)
+	if methodIndex == -1 {
+		methodIndex = wildIndex
+	}
+
+	e, params := router.findEntry(methodIndex, router.routingPath(r))
+	if e == nil {
+		return nil, nil
+	}
+
+	values := make(map[string]string, len(params))
+	for i, p := range params {
+		if e.params[i] == subtreeParam {
+			continue
+		}
+
+		value := p.Value
+		if router.UseEscapedPath {
+			if unescaped, err := url.PathUnescape(value); err == nil {
+				value = unescaped
+			}
+		}
+
+		values[e.params[i]] = value
+	}
+
+	ep := e.endpoint
+	if !ep.dispatched() {
+		return ep.routes[0], values
+	}
+
+	w := discardWriter{header: make(http.Header)}
+
+	routes, r := ep.selectMedia(w, r, ep.candidates(r))
+	if route := ep.selectVersion(w, r, routes); route != nil {
+		return route, values
+	}
+
+	return nil, nil
+}
+
+// discardWriter is a http.ResponseWriter writing nothing
+type discardWriter struct {
+	header http.Header
+}
+
+func (w discardWriter) Header() http.Header {
+	return w.header
+}
+
+func (w discardWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w discardWriter) WriteHeader(int) {}
+
+// wrap wraps the handler of the registered route with the middleware
+func (route *Route) wrap(m Middleware) {
+	route.handler = m(route.handler)
+
+	if ep := route.router.endpoints[route.method+" "+route.path]; ep != nil {
+		route.router.updateTree(ep)
+	}
+}
+
+// Method returns the HTTP method of the route
+func (route *Route) Method() string {
+	return route.method
+}
+
+// Path returns the path pattern of the route, as registered
+func (route *Route) Path() string {
+	return route.path
+}
+
+// GetName returns the name of the route
+func (route *Route) GetName() string {
+	return route.name
+}
+
+// Name sets the name of the route.
+// The name is used as OpenAPI operationId and must be unique per router.
+func (route *Route) Name(name string) *Route {
+	if name == route.name {
+		return route
+	}
+
+	if _, ok := route.router.namedRoutes[name]; ok {
+		panic("a route named '" + name + "' is already registered")
+	}
+
+	delete(route.router.namedRoutes, route.name)
+
+	route.name = name
+	route.router.namedRoutes[name] = route
+
+	return route
+}
+
+// Summary sets a short summary of what the route does
+func (route *Route) Summary(summary string) *Route {
+	route.summary = summary
+
+	return route
+}
+
+// Description sets a verbose explanation of the route behavior
+func (route *Route) Description(description string) *Route {
+	route.description = description
+
+	return route
+}
+
+// Tags adds tags for logical grouping of routes in the documentation
+func (route *Route) Tags(tags ...string) *Route {
+	route.tags = append(route.tags, tags...)
+
+	return route
+}
+
+// Deprecated marks the route as deprecated in the documentation
+func (route *Route) Deprecated() *Route {
+	route.deprecated = true
+
+	return route
+}
+
+// Param sets the description of the path param with the given name
+func (route *Route) Param(name, description string) *Route {
+	if route.paramDocs == nil {
+		route.paramDocs = make(map[string]string)
+	}
+
+	route.paramDocs[name] = description
+
+	return route
+}
+
+// Request sets the type of the JSON request body.
+// A value (or a nil pointer) of the type must be passed, its schema is
+// derived by reflection.
+func (route *Route) Request(body interface{}) *Route {
+	route.requestBody = body
+
+	return route
+}
+
+// Response documents a response with the given status code.
+// body is a value of the type of the JSON response body, or nil if the
+// response has no body.
+func (route *Route) Response(code int, description string, body interface{}) *Route {
+	route.responses = append(route.responses, routeResponse{
+		code:        code,
+		description: description,
+		body:        body,
+	})
+
+	return route
+}
@@ -13,6 +13,9 @@ import (
 // MethodWild wild HTTP method
 const MethodWild = "*"
 
+// wildIndex is the index of MethodWild in the method tables
+const wildIndex = 9
+
 var (
 	defaultContentType = []byte("text/plain; charset=utf-8")
 	questionMark       = byte('?')
@@ -25,10 +28,16 @@ var (
 // New returns a new router.
 // Path auto-correction, including trailing slashes, is enabled by default.
 func New() *Router {
+	tree := radix.New()
+
 	return &Router{
-		trees:                  make([]*radix.Tree, 10),
+		tree:                   tree,
+		matcher:                tree,
+		tables:                 make(map[string]*methodTable),
 		customMethodsIndex:     make(map[string]int),
 		registeredPaths:        make(map[string][]string),
+		namedRoutes:            make(map[string]*Route),
+		endpoints:              make(map[string]*endpoint),
 		RedirectTrailingSlash:  true,
 		RedirectFixedPath:      true,
 		HandleMethodNotAllowed: true,
@@ -80,7 +89,7 @@ func (router *Router) methodIndexOf(method string) int {
 	case http.MethodTrace:
 		return 8
 	case MethodWild:
-		return 9
+		return wildIndex
 	}
 
 	if i, ok := router.customMethodsIndex[method]; ok {
@@ -97,14 +106,7 @@ func (router *Router) methodIndexOf(method string) int {
 // WARNING: Use with care. It could generate unexpected behaviours
 func (router *Router) Mutable(v bool) {
 	router.treeMutable = v
-
-	for i := range router.trees {
-		tree := router.trees[i]
-
-		if tree != nil {
-			tree.Mutable = v
-		}
-	}
+	router.tree.Mutable = v
 }
 
 // List returns all registered routes grouped by method
@@ -221,52 +223,176 @@ func (router *Router) ServeFilesCustom(path string, fs http.FileSystem) {
 // frequently used, non-standardized or custom methods (e.g. for internal
 // communication with a proxy).
 func (router *Router) Handle(method, path string, handler http.HandlerFunc) {
+	router.HandleRoute(method, path, handler)
+}
+
+// HandleRoute registers a new request handler like Handle does and returns
+// the registered route, so optional metadata can be attached to it.
+func (router *Router) HandleRoute(method, path string, handler http.HandlerFunc) *Route {
+	return router.handle(&Route{method: method, path: path, handler: handler})
+}
+
+// handle registers the given route.
+// If a route is already registered with the same method and path, the
+// route is added as a variant of its endpoint.
+func (router *Router) handle(route *Route) *Route {
+	route.path = router.translatePath(route.path)
+
 	switch {
-	case len(method) == 0:
+	case len(route.method) == 0:
 		panic("method must not be empty")
-	case handler == nil:
+	case route.handler == nil:
 		panic("handler must not be nil")
 	default:
-		validatePath(path)
+		validatePath(route.path)
+	}
+
+	method, path := route.method, route.path
+	route.router = router
+
+	if router.group != nil {
+		router.group.resolve(route)
 	}
 
+	if router.UseEscapedPath || router.CatchAllDotDot != DotDotAllow {
+		if params := patternParams(path); len(params) > 0 {
+			route.handler = router.decodeParams(params, route.handler)
+		}
+	}
+
+	if router.SaveMatchedRoutePath {
+		route.handler = router.saveMatchedRoutePath(path, route.handler)
+	}
+
+	if route.consumes != nil || route.produces != nil {
+		route.negotiate()
+	}
+
+	key := method + " " + path
+	if ep := router.endpoints[key]; ep != nil {
+		if existing := ep.add(route); existing != nil {
+			return existing
+		}
+
+		router.routes = append(router.routes, route)
+
+		return route
+	}
+
+	ep := &endpoint{
+		router: router,
+		method: method,
+		path:   path,
+		routes: []*Route{route},
+	}
+
+	router.addToTree(ep)
+
 	router.registeredPaths[method] = append(router.registeredPaths[method], path)
+	router.endpoints[key] = ep
+	router.routes = append(router.routes, route)
 
-	methodIndex := router.methodIndexOf(method)
+	return route
+}
+
+// addToTree adds the endpoint to the method tables of the tree, for all the
+// variants of the path
+func (router *Router) addToTree(ep *endpoint) {
+	methodIndex := router.methodIndexOf(ep.method)
 	if methodIndex == -1 {
-		tree := radix.New()
-		tree.Mutable = router.treeMutable
+		methodIndex = wildIndex + 1 + len(router.customMethodsIndex)
+		router.customMethodsIndex[ep.method] = methodIndex
+	}
 
-		router.trees = append(router.trees, tree)
-		methodIndex = len(router.trees) - 1
-		router.customMethodsIndex[method] = methodIndex
+	paths := getOptionalPaths(ep.path)
+
+	// if not has optional paths, adds the original
+	if len(paths) == 0 {
+		paths = []string{ep.path}
 	}
 
-	tree := router.trees[methodIndex]
-	if tree == nil {
-		tree = radix.New()
-		tree.Mutable = router.treeMutable
+	for _, path := range paths {
+		if router.UseEscapedPath {
+			path = escapePattern(path)
+		}
+
+		shape, params := pathShape(path)
+
+		table := router.tables[shape]
+		if table == nil {
+			table = &methodTable{path: path, segments: shapeSegments(shape)}
+			table.link(&router.shapes)
+			router.insert(path, table)
+			router.tables[shape] = table
+		} else if e := table.entry(methodIndex); e != nil && e.endpoint.method == ep.method && !router.treeMutable {
+			if e.endpoint.path != ep.path {
+				panic(fmt.Sprintf("path '%s' conflicts with the registered path '%s'", path, e.endpoint.path))
+			}
+
+			panic(fmt.Sprintf("a handler is already registered for path '%s'", path))
+		}
 
-		router.trees[methodIndex] = tree
-		router.globalAllowed = router.allowed("*", "")
+		table.set(methodIndex, &tableEntry{endpoint: ep, handler: ep.handler(), params: params})
+		table.updateAllow(router)
+		ep.tables = append(ep.tables, table)
 	}
 
-	if router.SaveMatchedRoutePath {
-		handler = router.saveMatchedRoutePath(path, handler)
+	if _, ok := router.registeredPaths[ep.method]; !ok && ep.method != http.MethodOptions {
+		methods := make([]string, 0, len(router.registeredPaths)+1)
+		for method := range router.registeredPaths {
+			if method != http.MethodOptions {
+				methods = append(methods, method)
+			}
+		}
+
+		router.globalAllowed = router.joinAllowed(append(methods, ep.method))
 	}
+}
 
-	optionalPaths := getOptionalPaths(path)
+// updateTree updates the handler of the endpoint in its method tables
+func (router *Router) updateTree(ep *endpoint) {
+	methodIndex := router.methodIndexOf(ep.method)
 
-	// if not has optional paths, adds the original
-	if len(optionalPaths) == 0 {
-		tree.Add(path, handler)
-	} else {
-		for _, p := range optionalPaths {
-			tree.Add(p, handler)
+	for _, table := range ep.tables {
+		if e := table.entries[methodIndex]; e != nil && e.endpoint == ep {
+			e.handler = ep.handler()
 		}
 	}
 }
 
+// find returns the method table of the path and the values of its params.
+// If the path has no table, it reports whether the path with (without)
+// the trailing slash has one.
+func (router *Router) find(path string) (*methodTable, radix.Params, bool) {
+	v, params, tsr := router.matcher.Find(path)
+	if v == nil {
+		return nil, nil, tsr
+	}
+
+	table := router.tableOf(v)
+	if table == nil {
+		return nil, nil, false
+	}
+
+	return table, params, false
+}
+
+// findEntry returns the entry of the method for the path and the values
+// of its params
+// Paths with a handler for other methods only are skipped, so a less
+// specific path can match.
+func (router *Router) findEntry(methodIndex int, path string) (*tableEntry, radix.Params) {
+	v, params, _ := router.matcher.FindFunc(path, func(v interface{}) bool {
+		table := router.tableOf(v)
+		return table != nil && table.entry(methodIndex) != nil
+	})
+	if v == nil {
+		return nil, nil
+	}
+
+	return router.tableOf(v).entry(methodIndex), params
+}
+
 // Lookup allows the manual lookup of a method + path combo.
 // This is e.g. useful to build a framework around this router.
 // If the path was found, it returns the handler function.
@@ -275,21 +401,33 @@ func (router *Router) Handle(method, path string, handler http.HandlerFunc) {
 func (router *Router) Lookup(method, path string, r *http.Request) (http.HandlerFunc, bool) {
 	methodIndex := router.methodIndexOf(method)
 	if methodIndex == -1 {
-		return nil, false
+		methodIndex = wildIndex
 	}
 
-	if tree := router.trees[methodIndex]; tree != nil {
-		handler, tsr := tree.Get(path, r)
-		if handler != nil || tsr {
-			return handler, tsr
+	if e, params := router.findEntry(methodIndex, path); e != nil {
+		if r == nil {
+			return e.handler, false
 		}
+
+		return e.bind(e.handler, params), false
+	}
+
+	return nil, router.trailingSlashTarget(methodIndex, path) != ""
+}
+
+// trailingSlashTarget returns the path with (without) the trailing slash,
+// if it has a handler for the method
+func (router *Router) trailingSlashTarget(methodIndex int, path string) string {
+	target := path + "/"
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		target = path[:len(path)-1]
 	}
 
-	if tree := router.trees[router.methodIndexOf(MethodWild)]; tree != nil {
-		return tree.Get(path, r)
+	if e, _ := router.findEntry(methodIndex, target); e != nil {
+		return target
 	}
 
-	return nil, false
+	return ""
 }
 
 func (router *Router) recv(w http.ResponseWriter, r *http.Request) {
@@ -299,110 +437,113 @@ func (router *Router) recv(w http.ResponseWriter, r *http.Request) {
 }
 
 func (router *Router) allowed(path, reqMethod string) (allow string) {
-	allowed := make([]string, 0, 9)
-
-	if path == "*" || path == "/*" { // server-wide{ // server-wide
-		// empty method is used for internal calls to refresh the cache
-		if reqMethod == "" {
-			for method := range router.registeredPaths {
-				if method == http.MethodOptions {
-					continue
-				}
-				// Add request method to list of allowed methods
-				allowed = append(allowed, method)
-			}
-		} else {
-			return router.globalAllowed
-		}
-	} else { // specific path
-		for method := range router.registeredPaths {
-			// Skip the requested method - we already tried this one
-			if method == reqMethod || method == http.MethodOptions {
-				continue
-			}
+	return router.allowedFor(path, reqMethod, nil)
+}
 
-			handle, _ := router.trees[router.methodIndexOf(method)].Get(path, nil)
-			if handle != nil {
-				// Add request method to list of allowed methods
-				allowed = append(allowed, method)
-			}
-		}
-	}
+// allowedFor returns the methods allowed for the path.
+// If the request is given, only the methods whose routes match the request
+// are allowed.
+func (router *Router) allowedFor(path, reqMethod string, r *http.Request) (allow string) {
+	table, _, _ := router.find(path)
 
-	if len(allowed) > 0 {
-		// Add request method to list of allowed methods
-		allowed = append(allowed, http.MethodOptions)
+	return router.allowedIn(path, table, reqMethod, r)
+}
 
-		// Sort allowed methods.
-		// sort.Strings(allowed) unfortunately causes unnecessary allocations
-		// due to allowed being moved to the heap and interface conversion
-		for i, l := 1, len(allowed); i < l; i++ {
-			for j := i; j > 0 && allowed[j] < allowed[j-1]; j-- {
-				allowed[j], allowed[j-1] = allowed[j-1], allowed[j]
-			}
-		}
+// allowedIn returns the methods allowed for the path, i.e. the methods
+// whose routes would serve it, including from less specific paths
+func (router *Router) allowedIn(path string, table *methodTable, reqMethod string, r *http.Request) string {
+	if path == "*" || path == "/*" { // server-wide
+		return router.globalAllowed
+	}
 
-		// return as comma separated list
-		return strings.Join(allowed, ", ")
+	if table == nil {
+		return ""
 	}
-	return
-}
 
-func (router *Router) tryRedirect(w http.ResponseWriter, r *http.Request, tree *radix.Tree, tsr bool, method, path string) bool {
-	// Moved Permanently, request with GET method
-	code := http.StatusMovedPermanently
-	if method != http.MethodGet {
-		// Permanent Redirect, request with same method
-		code = http.StatusPermanentRedirect
+	if table.precomputed(reqMethod, r) {
+		return table.allow
 	}
 
-	if tsr && router.RedirectTrailingSlash {
-		uri := bytebufferpool.Get()
+	var allowed []string
 
-		if len(path) > 1 && path[len(path)-1] == '/' {
-			uri.SetString(path[:len(path)-1])
-		} else {
-			uri.SetString(path)
-			uri.WriteByte('/')
+	// Visit all the tables matching the path, from the most specific
+	router.matcher.FindFunc(path, func(v interface{}) bool {
+		table := router.tableOf(v)
+		if table == nil {
+			return false
 		}
 
-		if queryBuf := r.URL.RawQuery; len(queryBuf) > 0 {
-			uri.WriteByte(questionMark)
-			uri.Write([]byte(queryBuf))
+		for _, e := range table.entries {
+			if e == nil || containsString(allowed, e.endpoint.method) {
+				continue
+			}
+
+			// Skip the requested method - we already tried this one
+			method := e.endpoint.method
+			if method == reqMethod || method == http.MethodOptions {
+				continue
+			}
+
+			if r == nil || !e.endpoint.hasPredicates() || len(e.endpoint.candidates(r)) > 0 {
+				allowed = append(allowed, method)
+			}
 		}
 
-		http.Redirect(w, r, uri.String(), code)
-		// ctx.Redirect(uri.String(), code)
-		bytebufferpool.Put(uri)
+		return false
+	})
+
+	return router.joinAllowed(allowed)
+}
 
-		return true
+// tryRedirect redirects the request to the path with (without) the trailing
+// slash or to the fixed path, if they have a handler for the method.
+// If the path has a method table, the fixed path is only searched if the
+// path isn't clean, since it would be the path itself otherwise.
+func (router *Router) tryRedirect(w http.ResponseWriter, r *http.Request, methodIndex int, path string, exact bool) bool {
+	if target := router.trailingSlashTarget(methodIndex, path); target != "" {
+		e, params := router.findEntry(methodIndex, target)
+		policy := e.endpoint.redirectPolicy()
+
+		switch {
+		case policy.Disabled || policy.TrailingSlash == TrailingSlashStrict:
+			return false
+		case policy.TrailingSlash == TrailingSlashLoose:
+			e.serve(w, r, params)
+			return true
+		case router.RedirectTrailingSlash:
+			router.redirect(w, r, policy, target)
+			return true
+		}
 	}
 
 	// Try to fix the request path
-	if router.RedirectFixedPath {
-		path2 := r.URL.RawPath
-
+	if router.RedirectFixedPath && (!exact || cleanPath(path) != path) {
 		uri := bytebufferpool.Get()
-		found := tree.FindCaseInsensitivePath(
-			cleanPath(path2),
+		defer bytebufferpool.Put(uri)
+
+		found := router.matcher.FindCaseInsensitivePath(
+			cleanPath(path),
 			router.RedirectTrailingSlash,
 			uri,
 		)
 
-		if found {
-			if queryBuf := r.URL.RawQuery; len(queryBuf) > 0 {
-				uri.WriteByte(questionMark)
-				uri.Write([]byte(queryBuf))
+		if found && uri.String() != path {
+			target := uri.String()
+
+			e, _ := router.findEntry(methodIndex, target)
+			if e == nil {
+				return false
+			}
+
+			policy := e.endpoint.redirectPolicy()
+			if policy.Disabled {
+				return false
 			}
 
-			// ctx.Redirect(uri.String(), code)
-			http.Redirect(w, r, uri.String(), code)
-			bytebufferpool.Put(uri)
+			router.redirect(w, r, policy, target)
 
 			return true
 		}
-
-		bytebufferpool.Put(uri)
 	}
 
 	return false
@@ -412,41 +553,62 @@ func (router *Router) tryRedirect(w http.ResponseWriter, r *http.Request, tree *
 func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if router.PanicHandler != nil {
 		defer router.recv(w, r)
+	} else if router.Problems != nil || router.ErrorHandler != nil || router.errorGroups {
+		defer router.recoverError(w, r)
 	}
 
-	path := r.URL.Path
+	path := router.routingPath(r)
 	method := r.Method
 	methodIndex := router.methodIndexOf(method)
 
-	if methodIndex > -1 {
-		if tree := router.trees[methodIndex]; tree != nil {
-			if handler, tsr := tree.Get(path, r); handler != nil {
-				handler.ServeHTTP(w, r)
-				return
-			} else if method != http.MethodConnect && path != "/" {
-				if ok := router.tryRedirect(w, r, tree, tsr, method, path); ok {
-					return
-				}
-			}
+	table, params, _ := router.find(path)
+	if table != nil {
+		if e := table.entry(methodIndex); e != nil {
+			e.serve(w, r, params)
+			return
+		}
+
+		// Try a less specific path
+		if e, params := router.findEntry(methodIndex, path); e != nil {
+			e.serve(w, r, params)
+			return
 		}
 	}
 
-	// Try to search in the wild method tree
-	if tree := router.trees[router.methodIndexOf(MethodWild)]; tree != nil {
-		if handler, tsr := tree.Get(path, r); handler != nil {
-			handler.ServeHTTP(w, r)
+	if method != http.MethodConnect && path != "/" {
+		if table == nil && router.serveCaseInsensitive(w, r, methodIndex, path) {
+			return
+		}
+
+		if table == nil && router.mediaVariants && router.serveMediaSuffix(w, r, methodIndex, path) {
+			return
+		}
+
+		if router.tryRedirect(w, r, methodIndex, path, table != nil) {
 			return
-		} else if method != http.MethodConnect && path != "/" {
-			if ok := router.tryRedirect(w, r, tree, tsr, method, path); ok {
-				return
-			}
 		}
 	}
 
+	router.reply(w, r, path, table)
+}
+
+// unmatched answers the requests which no route can serve, with the
+// allowed methods or 404 Not Found
+func (router *Router) unmatched(w http.ResponseWriter, r *http.Request) {
+	path := router.routingPath(r)
+	table, _, _ := router.find(path)
+
+	router.reply(w, r, path, table)
+}
+
+// reply answers the requests which no route of the method table can serve
+func (router *Router) reply(w http.ResponseWriter, r *http.Request, path string, table *methodTable) {
+	method := r.Method
+
 	if router.HandleOPTIONS && method == http.MethodOptions {
 		// Handle OPTIONS requests
 
-		if allow := router.allowed(path, http.MethodOptions); allow != "" {
+		if allow := router.allowedIn(path, table, http.MethodOptions, r); allow != "" {
 			w.Header().Set("Allow", allow)
 			if router.GlobalOPTIONS != nil {
 				router.GlobalOPTIONS.ServeHTTP(w, r)
@@ -456,12 +618,15 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	} else if router.HandleMethodNotAllowed {
 		// Handle 405
 
-		if allow := router.allowed(path, method); allow != "" {
+		if allow := router.allowedIn(path, table, method, r); allow != "" {
 			w.Header().Set("Allow", allow)
 			if router.MethodNotAllowed != nil {
 				router.MethodNotAllowed.ServeHTTP(w, r)
 			} else {
-				w.WriteHeader(http.StatusMethodNotAllowed)
+				router.writeProblem(w, r, http.StatusMethodNotAllowed, "", map[string]interface{}{
+					"allow":   strings.Split(allow, ", "),
+					"pattern": table.pattern(),
+				})
 			}
 			return
 		}
@@ -471,6 +636,6 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if router.NotFound != nil {
 		router.NotFound.ServeHTTP(w, r)
 	} else {
-		w.WriteHeader(http.StatusNotFound)
+		router.writeProblem(w, r, http.StatusNotFound, "", nil)
 	}
 }
@@ -383,10 +383,8 @@ func TestRouterMutable(t *testing.T) {
 		router.Handle(method, "/", handler1)
 	}
 
-	for method := range router.trees {
-		if !router.trees[method].Mutable {
-			t.Errorf("Method %d - Mutable == %v, want %v", method, router.trees[method].Mutable, true)
-		}
+	if !router.tree.Mutable {
+		t.Errorf("Tree Mutable == %v, want %v", router.tree.Mutable, true)
 	}
 
 	routes := []string{
@@ -1167,3 +1165,37 @@ func Benchmark_Get(b *testing.B) {
 		r.ServeHTTP(w, r0)
 	}
 }
+
+func TestRouterMatch(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	show := r.HandleRoute(http.MethodGet, "/users/{id}", handler)
+	v2 := r.HandleVersion(http.MethodGet, "/items", "2", handler)
+	v1 := r.HandleVersion(http.MethodGet, "/items", "1", handler)
+
+	tests := []struct {
+		path    string
+		version string
+		route   *Route
+		params  map[string]string
+	}{
+		{"/users/42", "", show, map[string]string{"id": "42"}},
+		{"/items", "", v2, map[string]string{}},
+		{"/items", "1", v1, map[string]string{}},
+		{"/items", "3", nil, nil},
+		{"/missing", "", nil, nil},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		if test.version != "" {
+			req.Header.Set("API-Version", test.version)
+		}
+
+		route, params := r.Match(req)
+		if route != test.route || !reflect.DeepEqual(params, test.params) {
+			t.Errorf("Match(%s, %q) == %v, %v, want %v, %v", test.path, test.version, route, params, test.route, test.params)
+		}
+	}
+}
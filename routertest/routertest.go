@@ -0,0 +1,221 @@
+// Package routertest provides helpers to test the routes of a router.
+package routertest
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router"
+)
+
+var update = flag.Bool("routertest.update", false, "update the golden route tables")
+
+// AssertRoute asserts that the request with the method and path is served by
+// the route with the given name and the given path params
+func AssertRoute(t testing.TB, r *router.Router, method, path, name string, params map[string]string) {
+	t.Helper()
+
+	route, values := r.Match(httptest.NewRequest(method, path, nil))
+	if route == nil {
+		t.Errorf("%s %s: no route matched, want %q", method, path, name)
+		return
+	}
+
+	if route.GetName() != name {
+		t.Errorf("%s %s: matched %s %s named %q, want %q", method, path, route.Method(), route.Path(), route.GetName(), name)
+	}
+
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	if !reflect.DeepEqual(values, params) {
+		t.Errorf("%s %s: params == %v, want %v", method, path, values, params)
+	}
+}
+
+// AssertNotFound asserts that the request with the method and path is
+// answered with 404 Not Found
+func AssertNotFound(t testing.TB, r *router.Router, method, path string) {
+	t.Helper()
+
+	w := serve(r, method, path)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("%s %s: code == %d, want %d", method, path, w.Code, http.StatusNotFound)
+	}
+}
+
+// AssertMethodNotAllowed asserts that the request with the method and path
+// is answered with 405 Method Not Allowed and the given allowed methods
+func AssertMethodNotAllowed(t testing.TB, r *router.Router, method, path string, allow ...string) {
+	t.Helper()
+
+	w := serve(r, method, path)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("%s %s: code == %d, want %d", method, path, w.Code, http.StatusMethodNotAllowed)
+		return
+	}
+
+	got := strings.Split(w.Header().Get("Allow"), ", ")
+	want := append([]string(nil), allow...)
+	sort.Strings(got)
+	sort.Strings(want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s %s: Allow == %q, want %q", method, path, w.Header().Get("Allow"), strings.Join(allow, ", "))
+	}
+}
+
+// AssertRedirect asserts that the request with the method and path is
+// redirected to the location with the status code
+func AssertRedirect(t testing.TB, r *router.Router, method, path string, code int, location string) {
+	t.Helper()
+
+	w := serve(r, method, path)
+
+	if w.Code != code {
+		t.Errorf("%s %s: code == %d, want %d", method, path, w.Code, code)
+	}
+
+	if got := w.Header().Get("Location"); got != location {
+		t.Errorf("%s %s: Location == %q, want %q", method, path, got, location)
+	}
+}
+
+func serve(r *router.Router, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+	return w
+}
+
+// RouteTable returns the registered routes of the router, one per line in
+// registration order, with their name and the requests they are selected
+// for
+func RouteTable(r *router.Router) string {
+	var b strings.Builder
+
+	for _, route := range r.Routes() {
+		fmt.Fprintf(&b, "%s %s", route.Method(), route.Path())
+
+		if name := route.GetName(); name != "" {
+			fmt.Fprintf(&b, " name=%s", name)
+		}
+
+		if version := route.GetVersion(); version != "" {
+			fmt.Fprintf(&b, " version=%s", version)
+		}
+
+		if mediaType := route.GetMediaType(); mediaType != "" {
+			fmt.Fprintf(&b, " media=%s", mediaType)
+		}
+
+		for _, p := range route.Predicates() {
+			desc := "custom"
+			if s, ok := p.(fmt.Stringer); ok {
+				desc = s.String()
+			}
+
+			fmt.Fprintf(&b, " when=%q", desc)
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// Golden compares the route table of the router to the golden file, and
+// reports the routes added, removed or moved since it was written.
+// The golden file is written when the tests run with -routertest.update.
+func Golden(t testing.TB, r *router.Router, file string) {
+	t.Helper()
+
+	table := RouteTable(r)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(file, []byte(table), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("%v (run the tests with -routertest.update to write it)", err)
+	}
+
+	if diff := diffRoutes(string(data), table); diff != "" {
+		t.Errorf("the routes differ from %s (run the tests with -routertest.update to accept them):\n%s", file, diff)
+	}
+}
+
+// diffRoutes describes the routes added, removed or moved between the
+// golden and actual route tables
+func diffRoutes(golden, actual string) string {
+	want, got := splitLines(golden), splitLines(actual)
+
+	wantIndex := make(map[string]int, len(want))
+	for i, line := range want {
+		wantIndex[line] = i
+	}
+
+	gotIndex := make(map[string]int, len(got))
+	for i, line := range got {
+		gotIndex[line] = i
+	}
+
+	var b strings.Builder
+
+	for _, line := range want {
+		if _, ok := gotIndex[line]; !ok {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	for _, line := range got {
+		if _, ok := wantIndex[line]; !ok {
+			fmt.Fprintf(&b, "+ %s\n", line)
+		}
+	}
+
+	// Compare the order of the routes present in both tables
+	var kept []string
+	for _, line := range got {
+		if _, ok := wantIndex[line]; ok {
+			kept = append(kept, line)
+		}
+	}
+
+	for i := 1; i < len(kept); i++ {
+		if wantIndex[kept[i]] < wantIndex[kept[i-1]] {
+			fmt.Fprintf(&b, "~ %s (moved before %s)\n", kept[i], kept[i-1])
+		}
+	}
+
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+
+	return lines
+}
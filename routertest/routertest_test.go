@@ -0,0 +1,121 @@
+package routertest
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router"
+)
+
+// recorder records the failures of the assertions
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func testRouter() *router.Router {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := router.New()
+	r.HandleRoute(http.MethodGet, "/users/{id}", handler).Name("user.show")
+	r.HandleRoute(http.MethodDelete, "/users/{userID}", handler).Name("user.delete")
+	r.HandleRoute(http.MethodGet, "/files/{path:*}", handler).Name("files")
+	r.HandleVersion(http.MethodGet, "/items", "2", handler).Name("items.v2")
+	r.HandleRoute(http.MethodGet, "/items", handler).Name("items")
+	r.HandleMedia(http.MethodGet, "/reports", "text/csv", handler)
+	r.HandleWhen(http.MethodGet, "/search", handler, router.QueryPresent("q")).Name("search")
+
+	return r
+}
+
+func TestAssertions(t *testing.T) {
+	r := testRouter()
+
+	AssertRoute(t, r, http.MethodGet, "/users/42", "user.show", map[string]string{"id": "42"})
+	AssertRoute(t, r, http.MethodDelete, "/users/42", "user.delete", map[string]string{"userID": "42"})
+	AssertRoute(t, r, http.MethodGet, "/files/a/b.txt", "files", map[string]string{"path": "a/b.txt"})
+	AssertRoute(t, r, http.MethodGet, "/items", "items.v2", nil)
+	AssertRoute(t, r, http.MethodGet, "/search?q=go", "search", nil)
+	AssertNotFound(t, r, http.MethodGet, "/missing")
+	AssertMethodNotAllowed(t, r, http.MethodPost, "/users/42", "DELETE", "GET", "OPTIONS")
+	AssertRedirect(t, r, http.MethodGet, "/users/42/", http.StatusMovedPermanently, "/users/42")
+
+	rec := &recorder{TB: t}
+
+	AssertRoute(rec, r, http.MethodGet, "/users/42", "user.delete", map[string]string{"id": "1"})
+	AssertRoute(rec, r, http.MethodGet, "/search", "search", nil)
+	AssertNotFound(rec, r, http.MethodGet, "/users/42")
+	AssertMethodNotAllowed(rec, r, http.MethodPost, "/users/42", "GET")
+	AssertRedirect(rec, r, http.MethodGet, "/users/42", http.StatusMovedPermanently, "/users/42/")
+
+	want := []string{
+		`GET /users/42: matched GET /users/{id} named "user.show", want "user.delete"`,
+		`GET /users/42: params == map[id:42], want map[id:1]`,
+		`GET /search: no route matched, want "search"`,
+		`GET /users/42: code == 200, want 404`,
+		`POST /users/42: Allow == "DELETE, GET, OPTIONS", want "GET"`,
+		`GET /users/42: code == 200, want 301`,
+		`GET /users/42: Location == "", want "/users/42/"`,
+	}
+
+	if strings.Join(rec.errors, "\n") != strings.Join(want, "\n") {
+		t.Errorf("errors ==\n%s\nwant\n%s", strings.Join(rec.errors, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestGolden(t *testing.T) {
+	r := testRouter()
+
+	Golden(t, r, filepath.Join("testdata", "routes.golden"))
+
+	if *update {
+		return
+	}
+
+	file := filepath.Join(t.TempDir(), "routes.golden")
+	if err := os.WriteFile(file, []byte(RouteTable(r)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r.HandleRoute(http.MethodPost, "/users", func(w http.ResponseWriter, req *http.Request) {}).Name("user.create")
+
+	rec := &recorder{TB: t}
+	Golden(rec, r, file)
+
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "+ POST /users name=user.create\n") {
+		t.Errorf("unexpected errors %q", rec.errors)
+	}
+}
+
+func TestDiffRoutes(t *testing.T) {
+	golden := "GET /a\nGET /b\nGET /c\n"
+
+	tests := []struct {
+		actual string
+		diff   string
+	}{
+		{"GET /a\nGET /b\nGET /c\n", ""},
+		{"GET /a\nGET /c\nGET /d\n", "- GET /b\n+ GET /d\n"},
+		{"GET /b\nGET /a\nGET /c\n", "~ GET /a (moved before GET /b)\n"},
+	}
+
+	for _, test := range tests {
+		if diff := diffRoutes(golden, test.actual); diff != test.diff {
+			t.Errorf("diffRoutes(%q) == %q, want %q", test.actual, diff, test.diff)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+// Command routevet checks the route patterns and registrations of
+// github.com/pedia/router. It runs with go vet:
+//
+//	go vet -vettool=$(which routevet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/pedia/router/routevet"
+)
+
+func main() {
+	unitchecker.Main(routevet.Analyzer)
+}
@@ -0,0 +1,17 @@
+module github.com/pedia/router/routevet
+
+go 1.22.0
+
+require (
+	github.com/pedia/router v0.0.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/savsgio/gotils v0.0.0-20230208104028-c358bd845dee // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
+
+replace github.com/pedia/router => ../
@@ -0,0 +1,166 @@
+// Package routevet provides an analyzer reporting the route patterns which
+// make the router panic at startup: invalid param syntax, empty param names,
+// catch-all params not at the end, invalid regular expressions, trailing
+// slashes on groups and duplicate or conflicting registrations.
+//
+// The patterns are checked by registering the constant routes of each
+// router on a scratch router, so the reported errors are the ones of the
+// router itself. The variants registered by HandleVersion, HandleMedia and
+// HandleWhen are checked too, with a unique version, media type or
+// predicate when it isn't a constant.
+package routevet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/pedia/router"
+	"github.com/pedia/router/internal/routescan"
+)
+
+// Analyzer reports the route patterns and registrations which make the
+// router panic
+var Analyzer = &analysis.Analyzer{
+	Name: "routevet",
+	Doc:  "check route patterns and registrations of github.com/pedia/router",
+	Run:  run,
+}
+
+func noop(w http.ResponseWriter, r *http.Request) {}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	checkGroups(pass)
+
+	scratches := make(map[types.Object]*router.Router)
+
+	for i, route := range routescan.Scan(pass.Fset, pass.Files, pass.TypesInfo) {
+		path, ok := constString(pass, route.PathArg)
+		if !ok {
+			continue
+		}
+
+		method := route.Method
+		if method == "?" {
+			method = http.MethodGet
+		}
+
+		check := func() { router.New().Handle(method, path, noop) }
+		if route.Func == "HandlePattern" {
+			check = func() { router.New().HandlePattern(path, noop) }
+		}
+
+		if rcv := recoverPanic(check); rcv != nil {
+			pass.Reportf(route.PathArg.Pos(), "invalid route pattern: %v", rcv)
+			continue
+		}
+
+		if route.Dynamic {
+			continue
+		}
+
+		scratch := scratches[route.Router]
+		if scratch == nil {
+			scratch = router.New()
+			scratches[route.Router] = scratch
+		}
+
+		if rcv := recoverPanic(func() { register(pass, scratch, route, i) }); rcv != nil {
+			pass.Reportf(route.PathArg.Pos(), "route %s %s: %v", route.Method, route.Host+route.Path, rcv)
+		}
+	}
+
+	return nil, nil
+}
+
+// register registers the route on the scratch router like its registration
+// call. The variants whose version or media type isn't a constant, and the
+// predicates, are replaced by values unique to the route i, so only their
+// shape conflicts are reported.
+func register(pass *analysis.Pass, scratch *router.Router, route *routescan.Route, i int) {
+	unique := "routevet-" + strconv.Itoa(i)
+
+	switch route.Func {
+	case "HandleVersion":
+		version, ok := constString(pass, route.Call.Args[2])
+		if !ok {
+			version = unique
+		}
+
+		scratch.HandleVersion(route.Method, route.Path, version, noop)
+	case "HandleMedia":
+		mediaType, ok := constString(pass, route.Call.Args[2])
+		if !ok {
+			mediaType = "application/x-" + unique
+		}
+
+		scratch.HandleMedia(route.Method, route.Path, mediaType, noop)
+	case "HandleWhen":
+		scratch.HandleWhen(route.Method, route.Path, noop, router.HeaderEquals("X-Routevet", unique))
+	case "HandlePattern":
+		pattern := route.Host + route.Path
+		if route.Method != router.MethodWild {
+			pattern = route.Method + " " + pattern
+		}
+
+		scratch.HandlePattern(pattern, noop)
+	default:
+		scratch.Handle(route.Method, route.Path, noop)
+	}
+}
+
+// checkGroups reports the invalid constant group paths
+func checkGroups(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Group" {
+				return true
+			}
+
+			recv := pass.TypesInfo.TypeOf(sel.X)
+			if !routescan.IsRouterType(recv, "Router") && !routescan.IsRouterType(recv, "Group") {
+				return true
+			}
+
+			path, ok := constString(pass, call.Args[0])
+			if !ok {
+				return true
+			}
+
+			if rcv := recoverPanic(func() { router.New().Group(path) }); rcv != nil {
+				pass.Reportf(call.Args[0].Pos(), "invalid group path: %v", rcv)
+			}
+
+			return true
+		})
+	}
+}
+
+func constString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+func recoverPanic(fn func()) (rcv interface{}) {
+	defer func() {
+		rcv = recover()
+	}()
+
+	fn()
+
+	return nil
+}
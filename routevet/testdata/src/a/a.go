@@ -0,0 +1,55 @@
+package a
+
+import (
+	"net/http"
+
+	"github.com/pedia/router"
+)
+
+const usersPath = "/users/{id}"
+
+func handler(w http.ResponseWriter, r *http.Request) {}
+
+func routes() {
+	r := router.New()
+	r.GET("/", handler)
+	r.GET(usersPath, handler)
+	r.GET("/users/{name}", handler) // want `route GET /users/\{name\}: path '/users/\{name\}' conflicts with the registered path '/users/\{id\}'`
+	r.POST(usersPath, handler)
+	r.Handle("POST", "/users/{id}", handler) // want `route POST /users/\{id\}: a handler is already registered for path '/users/\{id\}'`
+
+	r.GET("/files/{path:*}/raw", handler) // want `invalid route pattern: wildcard routes are only allowed at the end of the path in path '/files/\{path:\*\}/raw'`
+	r.GET("/bad/{:x}", handler)           // want `invalid route pattern: wildcards must be named with a non-empty name in path '/bad/\{:x\}'`
+	r.GET("/regex/{id:[0-9}", handler)    // want `invalid route pattern: .*`
+	r.GET("no-slash", handler)            // want `invalid route pattern: path must begin with '/' in path 'no-slash'`
+
+	api := r.Group("/api/") // want `invalid group path: group path must not end with a trailing slash`
+	v1 := r.Group("/v1")
+	v1.GET(usersPath, handler)
+	v1.Group("/admin").GET("/", handler)
+	v1.Handle("GET", "/users/{userID}", handler) // want `route GET /v1/users/\{userID\}: path '/v1/users/\{userID\}' conflicts with the registered path '/v1/users/\{id\}'`
+
+	r.HandleVersion("GET", "/", "2", handler)
+	r.HandleVersion("GET", "/", "2", handler) // want `route GET /: a handler for version '2' is already registered for path '/'`
+	r.HandleVersion("GET", "/", version(), handler)
+	r.HandleVersion("GET", "/users/{name}", version(), handler) // want `route GET /users/\{name\}: path '/users/\{name\}' conflicts with the registered path '/users/\{id\}'`
+	r.HandleMedia("GET", "/", "application/json", handler)
+	r.HandleMedia("GET", "/", "application/json", handler) // want `route GET /: a handler for media type 'application/json' is already registered for path '/'`
+	r.HandleWhen("GET", "/", handler, router.HeaderEquals("X-Beta", "1"))
+	r.HandleWhen("GET", "/users/{name}", handler, router.HeaderEquals("X-Beta", "1")) // want `route GET /users/\{name\}: path '/users/\{name\}' conflicts with the registered path '/users/\{id\}'`
+	r.HandlePattern("GET example.com/files/{path...}", handler)
+	r.HandlePattern("GET example.com/files/{path...}", handler) // want `route GET example.com/files/\{path\.\.\.\}: a handler for predicates 'host example.com' is already registered for path '/files/\{path:\*\}'`
+	r.HandlePattern("POST /users/{name}", handler)              // want `route POST /users/\{name\}: path '/users/\{name\}' conflicts with the registered path '/users/\{id\}'`
+	r.HandlePattern("GET /files/{path...}/raw", handler)        // want `invalid route pattern: invalid pattern 'GET /files/\{path\.\.\.\}/raw': \{path\.\.\.\} not at the end`
+	api.GET("/ok", handler)
+
+	other := router.New()
+	other.GET("/", handler)
+}
+
+func version() string { return "3" }
+
+func mount(g *router.Group, path string) {
+	g.GET(path, handler)
+	g.GET("/{id}{name}", handler) // want `invalid route pattern: the wildcards must be separated by at least 1 char`
+}
@@ -0,0 +1,38 @@
+// Package router is a stub of the router API for the analyzer tests
+package router
+
+import "net/http"
+
+type Router struct{}
+
+type Group struct{}
+
+type Route struct{}
+
+type Predicate interface{}
+
+func New() *Router { return &Router{} }
+
+func HeaderEquals(name, value string) Predicate { return nil }
+
+func (router *Router) Group(path string) *Group                                   { return nil }
+func (router *Router) Handle(method, path string, handler http.HandlerFunc)       {}
+func (router *Router) HandleRoute(method, path string, h http.HandlerFunc) *Route { return nil }
+func (router *Router) HandleVersion(method, path, version string, h http.HandlerFunc) *Route {
+	return nil
+}
+func (router *Router) HandleMedia(method, path, mediaType string, h http.HandlerFunc) *Route {
+	return nil
+}
+func (router *Router) HandleWhen(method, path string, h http.HandlerFunc, predicates ...Predicate) *Route {
+	return nil
+}
+func (router *Router) HandlePattern(pattern string, h http.HandlerFunc) *Route { return nil }
+func (router *Router) GET(path string, handler http.HandlerFunc)               {}
+func (router *Router) POST(path string, handler http.HandlerFunc)              {}
+
+func (g *Group) Group(path string) *Group                             { return nil }
+func (g *Group) GET(path string, handler http.HandlerFunc)            {}
+func (g *Group) Handle(method, path string, handler http.HandlerFunc) {}
+
+func (route *Route) Name(name string) *Route { return route }
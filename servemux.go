@@ -0,0 +1,231 @@
+package router
+
+import (
+	"fmt"
+	"go/token"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// subtreeParam is the name of the catch-all param matching the subtree of
+// the ServeMux patterns ending with a slash. Like in ServeMux, its value
+// isn't exposed to the handlers.
+const subtreeParam = "$subtree"
+
+// implicitHead is the HEAD route registered for a GET pattern, until a
+// HEAD pattern is registered for the same host and path
+type implicitHead struct {
+	route   *Route
+	handler http.HandlerFunc
+}
+
+// HandlePattern registers a new request handler with a net/http ServeMux
+// pattern, like "GET example.com/users/{id}", translated to the syntax of
+// the router:
+//   - patterns without method are registered for MethodWild
+//   - GET patterns also serve HEAD requests, until a HEAD pattern is
+//     registered for the same host and path
+//   - the hosts are matched by the Host predicate
+//   - {name...} is a catch-all param
+//   - the patterns ending with a slash match their subtree, unless they
+//     end with {$}
+//
+// The params are read by UserValue and, since Go 1.22, by
+// Request.PathValue.
+// Invalid patterns panic like in ServeMux.
+func (router *Router) HandlePattern(pattern string, handler http.HandlerFunc) *Route {
+	method, host, path, err := parseServeMuxPattern(pattern)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return router.handlePattern(method, host, path, handler)
+}
+
+// HandlePattern registers a new request handler with a net/http ServeMux
+// pattern, whose path is relative to the group. See Router.HandlePattern.
+func (g *Group) HandlePattern(pattern string, handler http.HandlerFunc) *Route {
+	method, host, path, err := parseServeMuxPattern(pattern)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	defer g.registering()()
+
+	return g.router.handlePattern(method, host, g.prefix+path, handler)
+}
+
+func (router *Router) handlePattern(method, host, path string, handler http.HandlerFunc) *Route {
+	var predicates []Predicate
+	if host != "" {
+		predicates = []Predicate{Host(host)}
+	}
+
+	register := func(method string, handler http.HandlerFunc) *Route {
+		if len(predicates) > 0 {
+			return router.HandleWhen(method, path, handler, predicates...)
+		}
+
+		return router.HandleRoute(method, path, handler)
+	}
+
+	key := host + path
+
+	if method == http.MethodHead {
+		if head := router.implicitHeads[key]; head != nil {
+			head.handler = handler
+			delete(router.implicitHeads, key)
+
+			return head.route
+		}
+	}
+
+	route := register(method, handler)
+
+	if method == http.MethodGet && !router.hasVariant(http.MethodHead, path, predicates) {
+		head := &implicitHead{handler: handler}
+		head.route = register(http.MethodHead, func(w http.ResponseWriter, r *http.Request) {
+			head.handler(w, r)
+		})
+
+		if router.implicitHeads == nil {
+			router.implicitHeads = make(map[string]*implicitHead)
+		}
+
+		router.implicitHeads[key] = head
+	}
+
+	return route
+}
+
+// hasVariant reports whether a route with the given predicates is
+// registered for the method and path
+func (router *Router) hasVariant(method, path string, predicates []Predicate) bool {
+	ep := router.endpoints[method+" "+path]
+	if ep == nil {
+		return false
+	}
+
+	variant := &Route{predicates: predicates}
+
+	for _, route := range ep.routes {
+		if route.sameVariant(variant) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseServeMuxPattern translates a ServeMux pattern to the method, host
+// and path of a route
+func parseServeMuxPattern(pattern string) (method, host, path string, err error) {
+	fail := func(format string, args ...interface{}) (string, string, string, error) {
+		return "", "", "", fmt.Errorf("invalid pattern '%s': %s", pattern, fmt.Sprintf(format, args...))
+	}
+
+	rest := pattern
+	if i := strings.IndexAny(rest, " \t"); i != -1 {
+		method, rest = rest[:i], strings.TrimLeft(rest[i+1:], " \t")
+
+		if !validMethod(method) {
+			return fail("invalid method '%s'", method)
+		}
+	}
+
+	if method == "" {
+		method = MethodWild
+	}
+
+	i := strings.IndexByte(rest, '/')
+	if i == -1 {
+		return fail("host/path is missing '/'")
+	}
+
+	host, rest = rest[:i], rest[i+1:]
+
+	var b strings.Builder
+
+	names := make(map[string]bool)
+	segments := strings.Split(rest, "/")
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		b.WriteByte('/')
+
+		switch {
+		case segment == "{$}":
+			if !last {
+				return fail("{$} not at the end")
+			}
+
+			return method, host, b.String(), nil
+
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name := segment[1 : len(segment)-1]
+
+			catchAll := strings.HasSuffix(name, "...")
+			if catchAll {
+				if !last {
+					return fail("{%s} not at the end", name)
+				}
+
+				name = strings.TrimSuffix(name, "...")
+			}
+
+			switch {
+			case !token.IsIdentifier(name):
+				return fail("bad wildcard name '%s'", name)
+			case names[name]:
+				return fail("duplicate wildcard name '%s'", name)
+			}
+
+			names[name] = true
+
+			if catchAll {
+				b.WriteString("{" + name + ":*}")
+			} else {
+				b.WriteString("{" + name + "}")
+			}
+
+		case strings.ContainsAny(segment, "{}"):
+			return fail("bad wildcard segment '%s'", segment)
+
+		case segment == "" && last:
+			// Matches the subtree
+			b.WriteString("{" + subtreeParam + ":*}")
+
+		default:
+			literal, err := url.PathUnescape(segment)
+			if err != nil {
+				return fail("%s", err)
+			} else if strings.ContainsAny(literal, "{}") {
+				return fail("unsupported escaped brace in segment '%s'", segment)
+			}
+
+			b.WriteString(literal)
+		}
+	}
+
+	return method, host, b.String(), nil
+}
+
+// validMethod reports whether the method is a valid HTTP token
+func validMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+
+	for _, c := range method {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
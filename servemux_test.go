@@ -0,0 +1,125 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseServeMuxPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		host    string
+		path    string
+	}{
+		{"/", MethodWild, "", "/{$subtree:*}"},
+		{"/{$}", MethodWild, "", "/"},
+		{"GET /users/{id}", http.MethodGet, "", "/users/{id}"},
+		{"POST  /users/{id}/posts/{$}", http.MethodPost, "", "/users/{id}/posts/"},
+		{"GET example.com/static/", http.MethodGet, "example.com", "/static/{$subtree:*}"},
+		{"/files/{path...}", MethodWild, "", "/files/{path:*}"},
+		{"DELETE /a%20b/{x}", http.MethodDelete, "", "/a b/{x}"},
+	}
+
+	for _, test := range tests {
+		method, host, path, err := parseServeMuxPattern(test.pattern)
+		if err != nil {
+			t.Errorf("%q: %v", test.pattern, err)
+			continue
+		}
+
+		if method != test.method || host != test.host || path != test.path {
+			t.Errorf("%q = %q, %q, %q, want %q, %q, %q", test.pattern, method, host, path, test.method, test.host, test.path)
+		}
+	}
+
+	for _, pattern := range []string{
+		"",
+		"GET",
+		"G(T /x",
+		"/{$}/x",
+		"/{path...}/x",
+		"/{1d}",
+		"/{a}/{a}",
+		"/a{b}",
+		"/%7Bx%7D",
+	} {
+		if _, _, _, err := parseServeMuxPattern(pattern); err == nil {
+			t.Errorf("%q didn't fail", pattern)
+		}
+	}
+}
+
+func TestRouterHandlePattern(t *testing.T) {
+	bodyHandler := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body + UserValue(r, "id") + UserValue(r, "path")))
+		}
+	}
+
+	r := New()
+	r.HandlePattern("GET /users/{id}", bodyHandler("user "))
+	r.HandlePattern("GET api.example.com/users/{id}", bodyHandler("api user "))
+	r.HandlePattern("GET /files/{path...}", bodyHandler("file "))
+	r.HandlePattern("HEAD /files/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Head", "explicit")
+	})
+	r.HandlePattern("/static/", func(w http.ResponseWriter, r *http.Request) {
+		// The subtree param is internal
+		w.Write([]byte("static" + strconv.Itoa(len(UserValues(r)))))
+	})
+	r.HandlePattern("/{$}", bodyHandler("home"))
+
+	g := r.Group("/v1")
+	g.HandlePattern("POST /items", bodyHandler("item"))
+
+	tests := []struct {
+		method string
+		url    string
+		code   int
+		body   string
+		head   string
+	}{
+		{http.MethodGet, "http://example.com/users/7", http.StatusOK, "user 7", ""},
+		{http.MethodHead, "http://example.com/users/7", http.StatusOK, "user 7", ""},
+		{http.MethodGet, "http://API.example.com:8080/users/7", http.StatusOK, "api user 7", ""},
+		{http.MethodHead, "http://api.example.com/users/7", http.StatusOK, "api user 7", ""},
+		{http.MethodGet, "http://example.com/files/a/b.txt", http.StatusOK, "file a/b.txt", ""},
+		{http.MethodHead, "http://example.com/files/a/b.txt", http.StatusOK, "", "explicit"},
+		{http.MethodPut, "http://example.com/static/css/site.css", http.StatusOK, "static0", ""},
+		{http.MethodGet, "http://example.com/static", http.StatusMovedPermanently, "", ""},
+		{http.MethodGet, "http://example.com/", http.StatusOK, "home", ""},
+		{http.MethodGet, "http://example.com/other", http.StatusNotFound, "", ""},
+		{http.MethodPost, "http://example.com/v1/items", http.StatusOK, "item", ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.url, nil))
+
+		if w.Code != test.code {
+			t.Errorf("%s %s: status %d, want %d", test.method, test.url, w.Code, test.code)
+		}
+
+		if test.code == http.StatusOK && w.Body.String() != test.body {
+			t.Errorf("%s %s: body %q, want %q", test.method, test.url, w.Body, test.body)
+		}
+
+		if got := w.Header().Get("X-Head"); got != test.head {
+			t.Errorf("%s %s: X-Head %q, want %q", test.method, test.url, got, test.head)
+		}
+	}
+
+	func() {
+		defer func() {
+			if err := recover(); err == nil || !strings.Contains(err.(string), "bad wildcard name") {
+				t.Errorf("invalid pattern panicked with %v", err)
+			}
+		}()
+
+		r.HandlePattern("GET /{a-b}", bodyHandler(""))
+	}()
+}
@@ -0,0 +1,343 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pedia/router/openapi"
+)
+
+// defaultMaxSpecBodySize is the size limit of the request bodies validated
+// by HandleSpec when Router.MaxSpecBodySize is not set
+const defaultMaxSpecBodySize = 10 << 20
+
+// errSpecBodyTooLarge is returned when the request body exceeds the limit
+var errSpecBodyTooLarge = errors.New("request body too large")
+
+// specMethods is the order in which the operations of a path are registered
+var specMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// SpecError is returned by HandleSpec when the operations of the document
+// and the given handlers don't match
+type SpecError struct {
+	// Operations of the document without handler, as operationId or as
+	// "METHOD /path" if the operation has no operationId
+	Unbound []string
+	// Handlers whose operationId is not declared by the document
+	Unknown []string
+}
+
+func (err *SpecError) Error() string {
+	msgs := make([]string, 0, 2)
+
+	if len(err.Unbound) > 0 {
+		msgs = append(msgs, "unbound operations: "+strings.Join(err.Unbound, ", "))
+	}
+
+	if len(err.Unknown) > 0 {
+		msgs = append(msgs, "unknown operations: "+strings.Join(err.Unknown, ", "))
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// HandleSpec registers the operations of the given OpenAPI 3 document,
+// binding them by operationId to the given handlers.
+//
+// Before invoking the handler, the path, query, header and cookie params
+// and the JSON request body are validated against the schemas of the
+// document. Invalid requests are answered with 400 Bad Request and the
+// list of violations, and the bodies larger than MaxSpecBodySize with 413
+// Request Entity Too Large.
+//
+// All bound operations are registered, even if a *SpecError is returned
+// because some operations have no handler or some handlers have no
+// operation. It's intended to be checked at startup.
+func (router *Router) HandleSpec(doc *openapi.Document, handlers map[string]http.HandlerFunc) error {
+	return router.handleSpec(doc, handlers, router.HandleRoute)
+}
+
+// HandleSpec registers the operations of the given OpenAPI 3 document,
+// prefixed with the group path. See Router.HandleSpec.
+func (g *Group) HandleSpec(doc *openapi.Document, handlers map[string]http.HandlerFunc) error {
+	return g.router.handleSpec(doc, handlers, g.HandleRoute)
+}
+
+func (router *Router) handleSpec(
+	doc *openapi.Document,
+	handlers map[string]http.HandlerFunc,
+	handle func(method, path string, handler http.HandlerFunc) *Route,
+) error {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	specErr := new(SpecError)
+	bound := make(map[string]bool)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		ops := item.Methods()
+
+		for _, method := range specMethods {
+			op := ops[method]
+			if op == nil {
+				continue
+			}
+
+			handler := handlers[op.OperationID]
+			if op.OperationID == "" || handler == nil {
+				id := op.OperationID
+				if id == "" {
+					id = method + " " + path
+				}
+
+				specErr.Unbound = append(specErr.Unbound, id)
+
+				continue
+			}
+
+			bound[op.OperationID] = true
+
+			v := newSpecValidator(router, doc, item, op)
+			route := handle(method, path, v.handler(handler))
+			route.Name(op.OperationID).
+				Summary(op.Summary).
+				Description(op.Description).
+				Tags(op.Tags...)
+
+			if op.Deprecated {
+				route.Deprecated()
+			}
+
+			for _, param := range v.params {
+				if param.In == "path" && param.Description != "" {
+					route.Param(param.Name, param.Description)
+				}
+			}
+		}
+	}
+
+	for id := range handlers {
+		if !bound[id] {
+			specErr.Unknown = append(specErr.Unknown, id)
+		}
+	}
+
+	sort.Strings(specErr.Unknown)
+
+	if len(specErr.Unbound) > 0 || len(specErr.Unknown) > 0 {
+		return specErr
+	}
+
+	return nil
+}
+
+// specValidator validates requests against an operation of a document
+type specValidator struct {
+	router *Router
+	doc    *openapi.Document
+	params []*openapi.Parameter
+	body   *openapi.RequestBody
+}
+
+func newSpecValidator(router *Router, doc *openapi.Document, item *openapi.PathItem, op *openapi.Operation) *specValidator {
+	v := &specValidator{
+		router: router,
+		doc:    doc,
+		body:   op.RequestBody,
+	}
+
+	// Operation params override the path item params with the same location
+	// and name
+	index := make(map[string]int)
+
+	for _, params := range [][]*openapi.Parameter{item.Parameters, op.Parameters} {
+		for _, param := range params {
+			param = doc.ResolveParameter(param)
+			if param == nil {
+				continue
+			}
+
+			key := param.In + ":" + param.Name
+			if i, ok := index[key]; ok {
+				v.params[i] = param
+				continue
+			}
+
+			index[key] = len(v.params)
+			v.params = append(v.params, param)
+		}
+	}
+
+	return v
+}
+
+func (v *specValidator) handler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		errs := v.validateParams(r)
+
+		bodyErrs, err := v.validateBody(w, r)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, errSpecBodyTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+
+			if v.router.Problems != nil {
+				v.router.writeProblem(w, r, status, err.Error(), nil)
+			} else {
+				http.Error(w, err.Error(), status)
+			}
+
+			return
+		}
+
+		errs = append(errs, bodyErrs...)
+
+		if len(errs) > 0 {
+			v.router.writeValidationErrors(w, r, errs)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+func (v *specValidator) validateParams(r *http.Request) ValidationErrors {
+	var errs ValidationErrors
+
+	for _, param := range v.params {
+		var values []string
+
+		switch param.In {
+		case "path":
+			if value, ok := UserValues(r)[param.Name]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = r.URL.Query()[param.Name]
+		case "header":
+			values = r.Header.Values(param.Name)
+		case "cookie":
+			if c, err := r.Cookie(param.Name); err == nil {
+				values = []string{c.Value}
+			}
+		}
+
+		if len(values) == 0 {
+			if param.Required || param.In == "path" {
+				errs = append(errs, FieldError{In: param.In, Name: param.Name, Message: "is required"})
+			}
+
+			continue
+		}
+
+		value, err := v.doc.ParseParam(param.Schema, values)
+		if err != nil {
+			errs = append(errs, FieldError{In: param.In, Name: param.Name, Message: err.Error()})
+			continue
+		}
+
+		for _, violation := range v.doc.Validate(param.Schema, value) {
+			errs = append(errs, FieldError{In: param.In, Name: param.Name + violation.Path, Message: violation.Message})
+		}
+	}
+
+	return errs
+}
+
+// validateBody validates the JSON request body, which is restored so the
+// handler can read it again
+func (v *specValidator) validateBody(w http.ResponseWriter, r *http.Request) (ValidationErrors, error) {
+	if v.body == nil {
+		return nil, nil
+	}
+
+	limit := v.router.MaxSpecBodySize
+	if limit <= 0 {
+		limit = defaultMaxSpecBodySize
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		// MaxBytesReader returns the bytes up to the limit before failing
+		if int64(len(data)) == limit {
+			return nil, errSpecBodyTooLarge
+		}
+
+		return nil, err
+	}
+
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(data) == 0 {
+		if v.body.Required {
+			return ValidationErrors{{In: "body", Message: "is required"}}, nil
+		}
+
+		return nil, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ValidationErrors{{In: "header", Name: "Content-Type", Message: err.Error()}}, nil
+	}
+
+	content, ok := v.body.Content[mediaType]
+	if !ok {
+		return ValidationErrors{{
+			In:      "header",
+			Name:    "Content-Type",
+			Message: fmt.Sprintf("unsupported media type %s", mediaType),
+		}}, nil
+	}
+
+	if content == nil || content.Schema == nil || !isJSONMediaType(mediaType) {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var body interface{}
+	if err := dec.Decode(&body); err != nil {
+		return ValidationErrors{{In: "body", Message: "invalid JSON: " + err.Error()}}, nil
+	}
+
+	var errs ValidationErrors
+	for _, violation := range v.doc.Validate(content.Schema, body) {
+		errs = append(errs, FieldError{In: "body", Name: violation.Path, Message: violation.Message})
+	}
+
+	return errs, nil
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
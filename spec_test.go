@@ -0,0 +1,175 @@
+package router
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pedia/router/openapi"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "pets", "version": "1.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+          {"name": "tags", "in": "query", "schema": {"type": "array", "items": {"type": "string", "enum": ["cat", "dog"]}}},
+          {"$ref": "#/components/parameters/RequestID"}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      },
+      "post": {
+        "operationId": "createPet",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+        },
+        "responses": {"201": {"description": "Created"}}
+      }
+    },
+    "/pets/{petId}": {
+      "parameters": [
+        {"name": "petId", "in": "path", "required": true, "schema": {"type": "string", "pattern": "^[0-9]+$"}}
+      ],
+      "get": {"operationId": "showPet", "responses": {"200": {"description": "OK"}}},
+      "delete": {"responses": {"204": {"description": "Deleted"}}}
+    }
+  },
+  "components": {
+    "parameters": {
+      "RequestID": {"name": "X-Request-ID", "in": "header", "schema": {"type": "string", "maxLength": 8}}
+    },
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"type": "string", "minLength": 1},
+          "age": {"type": "integer", "minimum": 0}
+        }
+      }
+    }
+  }
+}`
+
+func TestRouterHandleSpec(t *testing.T) {
+	doc, err := openapi.Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var called string
+	var body []byte
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			called = name
+			body, _ = io.ReadAll(r.Body)
+		}
+	}
+
+	r := New()
+	r.MaxSpecBodySize = 64
+	err = r.Group("/api").HandleSpec(doc, map[string]http.HandlerFunc{
+		"listPets":  handler("listPets"),
+		"createPet": handler("createPet"),
+		"showPet":   handler("showPet"),
+		"feedPet":   handler("feedPet"),
+	})
+
+	specErr, ok := err.(*SpecError)
+	if !ok {
+		t.Fatalf("error == %v, want *SpecError", err)
+	}
+
+	if !reflect.DeepEqual(specErr.Unbound, []string{"DELETE /pets/{petId}"}) {
+		t.Errorf("Unbound == %v", specErr.Unbound)
+	}
+
+	if !reflect.DeepEqual(specErr.Unknown, []string{"feedPet"}) {
+		t.Errorf("Unknown == %v", specErr.Unknown)
+	}
+
+	if route := r.NamedRoute("showPet"); route == nil || route.Path() != "/api/pets/{petId}" {
+		t.Errorf("unexpected showPet route: %v", route)
+	}
+
+	tests := []struct {
+		method, path, body string
+		header             http.Header
+		code               int
+		called             string
+		errors             ValidationErrors
+	}{
+		{"GET", "/api/pets?limit=10&tags=cat,dog", "", nil, http.StatusOK, "listPets", nil},
+		{"GET", "/api/pets?limit=0&tags=cow", "", http.Header{"X-Request-Id": {"123456789"}}, http.StatusBadRequest, "", ValidationErrors{
+			{In: "query", Name: "limit", Message: "must be greater than or equal to 1"},
+			{In: "query", Name: "tags/0", Message: "must be one of [cat, dog]"},
+			{In: "header", Name: "X-Request-ID", Message: "must be at most 8 characters long"},
+		}},
+		{"GET", "/api/pets?limit=ten", "", nil, http.StatusBadRequest, "", ValidationErrors{
+			{In: "query", Name: "limit", Message: "must be an integer"},
+		}},
+		{"GET", "/api/pets/12", "", nil, http.StatusOK, "showPet", nil},
+		{"GET", "/api/pets/rex", "", nil, http.StatusBadRequest, "", ValidationErrors{
+			{In: "path", Name: "petId", Message: "must match the pattern ^[0-9]+$"},
+		}},
+		{"POST", "/api/pets", `{"name":"rex","age":3}`, nil, http.StatusOK, "createPet", nil},
+		{"POST", "/api/pets", `{"name":"","age":-1}`, nil, http.StatusBadRequest, "", ValidationErrors{
+			{In: "body", Name: "/age", Message: "must be greater than or equal to 0"},
+			{In: "body", Name: "/name", Message: "must be at least 1 characters long"},
+		}},
+		{"POST", "/api/pets", `{"name":"` + strings.Repeat("x", 64) + `"}`, nil, http.StatusRequestEntityTooLarge, "", nil},
+		{"POST", "/api/pets", ``, nil, http.StatusBadRequest, "", ValidationErrors{
+			{In: "body", Message: "is required"},
+		}},
+		{"POST", "/api/pets", `{"age":1}`, http.Header{"Content-Type": {"text/plain"}}, http.StatusBadRequest, "", ValidationErrors{
+			{In: "header", Name: "Content-Type", Message: "unsupported media type text/plain"},
+		}},
+	}
+
+	for _, test := range tests {
+		called = ""
+
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code {
+			t.Errorf("%s %s: code == %d, want %d", test.method, test.path, w.Code, test.code)
+		}
+
+		if called != test.called {
+			t.Errorf("%s %s: called == %q, want %q", test.method, test.path, called, test.called)
+		}
+
+		if test.called != "" && string(body) != test.body {
+			t.Errorf("%s %s: body == %q, want %q", test.method, test.path, body, test.body)
+		}
+
+		if test.errors != nil {
+			var resp struct {
+				Errors ValidationErrors `json:"errors"`
+			}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(resp.Errors, test.errors) {
+				t.Errorf("%s %s: errors == %v, want %v", test.method, test.path, resp.Errors, test.errors)
+			}
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package router
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSSEHeartbeat = 15 * time.Second
+	defaultSSERetention = 5 * time.Minute
+	sseSubscriberBuffer = 64
+)
+
+// SSEEvent is a server-sent event
+type SSEEvent struct {
+	// ID is the id of the event, sent back by the clients in the
+	// Last-Event-ID header when they reconnect.
+	// The broker numbers the events published without id.
+	ID string
+
+	// Event is the type of the event, "message" if empty
+	Event string
+
+	// Data is the data of the event, sent in a data line per line
+	Data string
+
+	// Retry is the reconnection delay advised to the clients, if not zero
+	Retry time.Duration
+}
+
+// WriteTo writes the event in the text/event-stream format
+func (e SSEEvent) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	if e.ID != "" {
+		b.WriteString("id: " + sseField(e.ID) + "\n")
+	}
+
+	if e.Event != "" {
+		b.WriteString("event: " + sseField(e.Event) + "\n")
+	}
+
+	if e.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+
+	// A lone \r is a line break too, so it can't smuggle in a field
+	data := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(e.Data)
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+
+	b.WriteByte('\n')
+
+	return b.WriteTo(w)
+}
+
+// sseField removes the line breaks of a single line field
+func sseField(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// SSEBroker fans out the events published to a topic to the subscribers of
+// the topic, and keeps the last events of each topic so reconnecting
+// clients can resume from their Last-Event-ID.
+// Subscribers too slow to receive the events are disconnected, they
+// resume from the replay buffer when they reconnect.
+//
+// A topic is created by its first subscriber, the events published to a
+// topic nobody subscribed to are dropped. Once its last subscriber left,
+// the topic keeps its replay buffer, and buffers the published events, for
+// the Retention period, then it's removed.
+type SSEBroker struct {
+	// Retention is how long a topic is kept after its last subscriber
+	// left, 5 minutes by default
+	Retention time.Duration
+
+	replaySize int
+
+	mu        sync.Mutex
+	topics    map[string]*sseTopic
+	nextSweep time.Time
+}
+
+type sseTopic struct {
+	seq         uint64
+	replay      []SSEEvent
+	subscribers map[chan SSEEvent]struct{}
+	idleSince   time.Time
+}
+
+// NewSSEBroker returns a broker keeping the last replaySize events of each
+// topic
+func NewSSEBroker(replaySize int) *SSEBroker {
+	return &SSEBroker{
+		replaySize: replaySize,
+		topics:     make(map[string]*sseTopic),
+	}
+}
+
+// Publish sends the event to the subscribers of the topic, and returns its
+// id
+func (b *SSEBroker) Publish(topic string, event SSEEvent) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.liveTopic(topic, time.Now())
+	if t == nil {
+		return event.ID
+	}
+
+	t.seq++
+	if event.ID == "" {
+		event.ID = strconv.FormatUint(t.seq, 10)
+	}
+
+	if b.replaySize > 0 {
+		if len(t.replay) == b.replaySize {
+			t.replay = append(t.replay[:0], t.replay[1:]...)
+		}
+
+		t.replay = append(t.replay, event)
+	}
+
+	for ch := range t.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Too slow, the client resumes when it reconnects
+			delete(t.subscribers, ch)
+			close(ch)
+
+			if len(t.subscribers) == 0 {
+				t.idleSince = time.Now()
+			}
+		}
+	}
+
+	return event.ID
+}
+
+// Subscribe subscribes to the events of the topic.
+// It returns the events published after the given last event id still in
+// the replay buffer, or the whole buffer if the id isn't in it, and the
+// channel receiving the next events. The channel is closed if the
+// subscriber is too slow. Cancel must be called to unsubscribe.
+func (b *SSEBroker) Subscribe(topic, lastEventID string) (replay []SSEEvent, events <-chan SSEEvent, cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.liveTopic(topic, time.Now())
+	if t == nil {
+		t = &sseTopic{subscribers: make(map[chan SSEEvent]struct{})}
+		b.topics[topic] = t
+	}
+
+	if lastEventID != "" {
+		replay = t.replay
+
+		for i, e := range t.replay {
+			if e.ID == lastEventID {
+				replay = t.replay[i+1:]
+			}
+		}
+
+		replay = append([]SSEEvent(nil), replay...)
+	}
+
+	ch := make(chan SSEEvent, sseSubscriberBuffer)
+	t.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+
+	return replay, ch, func() {
+		once.Do(func() {
+			b.unsubscribe(topic, ch)
+		})
+	}
+}
+
+// Subscribers returns the number of subscribers of the topic
+func (b *SSEBroker) Subscribers(topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t := b.topics[topic]; t != nil {
+		return len(t.subscribers)
+	}
+
+	return 0
+}
+
+// liveTopic returns the topic, unless it doesn't exist or its retention
+// expired. The expired topics are removed, all of them at most once per
+// retention period.
+func (b *SSEBroker) liveTopic(name string, now time.Time) *sseTopic {
+	retention := b.Retention
+	if retention <= 0 {
+		retention = defaultSSERetention
+	}
+
+	if !now.Before(b.nextSweep) {
+		b.nextSweep = now.Add(retention)
+
+		for topic, t := range b.topics {
+			if t.expired(now, retention) {
+				delete(b.topics, topic)
+			}
+		}
+	}
+
+	t := b.topics[name]
+	if t != nil && t.expired(now, retention) {
+		delete(b.topics, name)
+		return nil
+	}
+
+	return t
+}
+
+// expired reports whether the topic has had no subscribers for longer than
+// the retention
+func (t *sseTopic) expired(now time.Time, retention time.Duration) bool {
+	return len(t.subscribers) == 0 && now.Sub(t.idleSince) >= retention
+}
+
+// unsubscribe removes the subscriber, and the topic if it has no
+// subscribers nor events to replay
+func (b *SSEBroker) unsubscribe(topic string, ch chan SSEEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topics[topic]
+	if t == nil {
+		return
+	}
+
+	if _, ok := t.subscribers[ch]; ok {
+		delete(t.subscribers, ch)
+		close(ch)
+	}
+
+	if len(t.subscribers) == 0 {
+		if len(t.replay) == 0 {
+			delete(b.topics, topic)
+			return
+		}
+
+		t.idleSince = time.Now()
+	}
+}
+
+// SSEConfig configures the routes registered with SSE
+type SSEConfig struct {
+	// Topic returns the topic of the request.
+	// By default, it's the values of the route params joined with "/", or
+	// the path of the route if it has no params.
+	Topic func(r *http.Request) string
+
+	// Heartbeat is the interval of the comments sent to keep idle
+	// connections open, 15s by default
+	Heartbeat time.Duration
+}
+
+// SSEOption configures a SSE route
+type SSEOption func(*SSEConfig)
+
+// SSETopic sets the function returning the topic of the requests
+func SSETopic(topic func(r *http.Request) string) SSEOption {
+	return func(c *SSEConfig) {
+		c.Topic = topic
+	}
+}
+
+// SSEHeartbeat sets the interval of the heartbeats
+func SSEHeartbeat(interval time.Duration) SSEOption {
+	return func(c *SSEConfig) {
+		c.Heartbeat = interval
+	}
+}
+
+// SSE registers a GET route streaming the events published to the broker
+// for the topic of the request, as server-sent events.
+// The clients reconnecting with a Last-Event-ID header first receive the
+// events they missed, if still in the replay buffer of the broker.
+// The subscription ends when the client disconnects.
+func (router *Router) SSE(path string, broker *SSEBroker, opts ...SSEOption) *Route {
+	if broker == nil {
+		panic("broker must not be nil")
+	}
+
+	config := &SSEConfig{Heartbeat: defaultSSEHeartbeat}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.Topic == nil {
+		params := patternParams(router.translatePath(path))
+		config.Topic = func(r *http.Request) string {
+			if len(params) == 0 {
+				return path
+			}
+
+			values := make([]string, len(params))
+			for i, param := range params {
+				values[i] = UserValue(r, param.name)
+			}
+
+			return strings.Join(values, "/")
+		}
+	}
+
+	return router.HandleRoute(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request) {
+		router.serveSSE(w, r, broker, config)
+	})
+}
+
+// SSE registers a GET route streaming server-sent events. See Router.SSE.
+func (g *Group) SSE(path string, broker *SSEBroker, opts ...SSEOption) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.SSE(g.prefix+path, broker, opts...)
+}
+
+func (router *Router) serveSSE(w http.ResponseWriter, r *http.Request, broker *SSEBroker, config *SSEConfig) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		router.writeProblem(w, r, http.StatusInternalServerError, "streaming unsupported", nil)
+		return
+	}
+
+	replay, events, cancel := broker.Subscribe(config.Topic(r), r.Header.Get("Last-Event-ID"))
+	defer cancel()
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range replay {
+		if _, err := e.WriteTo(w); err != nil {
+			return
+		}
+	}
+
+	flusher.Flush()
+
+	var heartbeat <-chan time.Time
+	if config.Heartbeat > 0 {
+		ticker := time.NewTicker(config.Heartbeat)
+		defer ticker.Stop()
+
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if _, err := e.WriteTo(w); err != nil {
+				return
+			}
+		case <-heartbeat:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,335 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseClient reads the events of a SSE stream
+type sseClient struct {
+	resp   *http.Response
+	br     *bufio.Reader
+	cancel context.CancelFunc
+}
+
+// dialSSE opens a SSE stream, resuming after the last event id if any
+func dialSSE(t *testing.T, server *httptest.Server, path, lastEventID string) *sseClient {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+
+	return &sseClient{resp: resp, br: bufio.NewReader(resp.Body), cancel: cancel}
+}
+
+// next returns the lines of the next event or comment
+func (c *sseClient) next(t *testing.T) string {
+	t.Helper()
+
+	var lines []string
+
+	for {
+		line, err := c.br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "|")
+		}
+
+		lines = append(lines, line)
+	}
+}
+
+func (c *sseClient) close() {
+	c.cancel()
+	c.resp.Body.Close()
+}
+
+// waitSubscribers waits for the number of subscribers of the topic
+func waitSubscribers(t *testing.T, broker *SSEBroker, topic string, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for broker.Subscribers(topic) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("topic %s has %d subscribers, want %d", topic, broker.Subscribers(topic), n)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSSEEventWriteTo(t *testing.T) {
+	tests := []struct {
+		event SSEEvent
+		want  string
+	}{
+		{SSEEvent{Data: "hello"}, "data: hello\n\n"},
+		{SSEEvent{Data: ""}, "data: \n\n"},
+		{
+			SSEEvent{ID: "1", Event: "update", Data: "a\nb\r\nc", Retry: 2 * time.Second},
+			"id: 1\nevent: update\nretry: 2000\ndata: a\ndata: b\ndata: c\n\n",
+		},
+		{SSEEvent{ID: "a\nb", Event: "x\r\ny", Data: "z"}, "id: ab\nevent: xy\ndata: z\n\n"},
+		{SSEEvent{Data: "x\rid: 999"}, "data: x\ndata: id: 999\n\n"},
+	}
+
+	for _, test := range tests {
+		var b strings.Builder
+
+		n, err := test.event.WriteTo(&b)
+		if err != nil || b.String() != test.want || n != int64(len(test.want)) {
+			t.Errorf("%+v: wrote %q (%d, %v), want %q", test.event, b.String(), n, err, test.want)
+		}
+	}
+}
+
+func TestRouterSSE(t *testing.T) {
+	broker := NewSSEBroker(3)
+
+	r := New()
+	r.SSE("/events/{topic}", broker)
+	r.Group("/orgs").SSE("/{org}/events/{topic}", broker)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	news1 := dialSSE(t, server, "/events/news", "")
+	defer news1.close()
+
+	news2 := dialSSE(t, server, "/events/news", "")
+	defer news2.close()
+
+	sports := dialSSE(t, server, "/events/sports", "")
+	defer sports.close()
+
+	acme := dialSSE(t, server, "/orgs/acme/events/news", "")
+	defer acme.close()
+
+	h := news1.resp.Header
+	if h.Get("Content-Type") != "text/event-stream" || h.Get("Cache-Control") != "no-cache" {
+		t.Errorf("headers %v", h)
+	}
+
+	waitSubscribers(t, broker, "news", 2)
+	waitSubscribers(t, broker, "sports", 1)
+	waitSubscribers(t, broker, "acme/news", 1)
+
+	if id := broker.Publish("news", SSEEvent{Data: "first"}); id != "1" {
+		t.Errorf("id %q, want 1", id)
+	}
+
+	broker.Publish("sports", SSEEvent{Event: "score", Data: "1-0"})
+	broker.Publish("acme/news", SSEEvent{ID: "custom", Data: "acme"})
+	broker.Publish("news", SSEEvent{Data: "second"})
+
+	for _, c := range []*sseClient{news1, news2} {
+		if got := c.next(t); got != "id: 1|data: first" {
+			t.Errorf("event %q", got)
+		}
+
+		if got := c.next(t); got != "id: 2|data: second" {
+			t.Errorf("event %q", got)
+		}
+	}
+
+	if got := sports.next(t); got != "id: 1|event: score|data: 1-0" {
+		t.Errorf("event %q", got)
+	}
+
+	if got := acme.next(t); got != "id: custom|data: acme" {
+		t.Errorf("event %q", got)
+	}
+
+	// The subscriptions end when the clients disconnect
+	news1.close()
+	waitSubscribers(t, broker, "news", 1)
+
+	sports.close()
+	acme.close()
+	waitSubscribers(t, broker, "sports", 0)
+	waitSubscribers(t, broker, "acme/news", 0)
+}
+
+func TestRouterSSEResume(t *testing.T) {
+	broker := NewSSEBroker(3)
+
+	r := New()
+	r.SSE("/events/{topic}", broker)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	tests := []struct {
+		lastEventID string
+		want        []string
+	}{
+		// The events after the last one received
+		{"3", []string{"id: 4|data: d", "id: 5|data: e"}},
+		// Up to date
+		{"5", nil},
+		// Out of the buffer, everything kept is replayed
+		{"1", []string{"id: 3|data: c", "id: 4|data: d", "id: 5|data: e"}},
+		// No resume
+		{"", nil},
+	}
+
+	for i, test := range tests {
+		// The events are published while the previous client was connected
+		topic := "news" + strconv.Itoa(i)
+		_, _, cancel := broker.Subscribe(topic, "")
+		for _, data := range []string{"a", "b", "c", "d", "e"} {
+			broker.Publish(topic, SSEEvent{Data: data})
+		}
+		cancel()
+
+		c := dialSSE(t, server, "/events/"+topic, test.lastEventID)
+		waitSubscribers(t, broker, topic, 1)
+
+		for _, want := range test.want {
+			if got := c.next(t); got != want {
+				t.Errorf("Last-Event-ID %q: event %q, want %q", test.lastEventID, got, want)
+			}
+		}
+
+		broker.Publish(topic, SSEEvent{ID: "live", Data: "live"})
+
+		if got := c.next(t); got != "id: live|data: live" {
+			t.Errorf("Last-Event-ID %q: event %q, want the live event", test.lastEventID, got)
+		}
+
+		c.close()
+		waitSubscribers(t, broker, topic, 0)
+	}
+}
+
+func TestRouterSSEHeartbeat(t *testing.T) {
+	broker := NewSSEBroker(0)
+
+	r := New()
+	r.SSE("/events", broker, SSEHeartbeat(10*time.Millisecond), SSETopic(func(r *http.Request) string {
+		return r.URL.Query().Get("topic")
+	}))
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	c := dialSSE(t, server, "/events?topic=ticks", "")
+	defer c.close()
+
+	if got := c.next(t); got != ": heartbeat" {
+		t.Errorf("comment %q, want a heartbeat", got)
+	}
+
+	waitSubscribers(t, broker, "ticks", 1)
+}
+
+func TestSSEBrokerSlowSubscriber(t *testing.T) {
+	broker := NewSSEBroker(0)
+
+	_, events, cancel := broker.Subscribe("news", "")
+	defer cancel()
+
+	for i := 0; i <= sseSubscriberBuffer; i++ {
+		broker.Publish("news", SSEEvent{Data: "x"})
+	}
+
+	if n := broker.Subscribers("news"); n != 0 {
+		t.Errorf("%d subscribers, want the slow one removed", n)
+	}
+
+	n := 0
+	for range events {
+		n++
+	}
+
+	if n != sseSubscriberBuffer {
+		t.Errorf("received %d events, want %d", n, sseSubscriberBuffer)
+	}
+
+	// Cancelling after the removal is harmless
+	cancel()
+
+	if len(broker.topics) != 0 {
+		t.Errorf("topics %v, want them cleaned up", broker.topics)
+	}
+}
+
+func TestRouterSSENoFlusher(t *testing.T) {
+	r := New()
+	r.SSE("/events", NewSSEBroker(1))
+
+	w := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if code := w.ResponseWriter.(*httptest.ResponseRecorder).Code; code != http.StatusInternalServerError {
+		t.Errorf("status %d, want %d", code, http.StatusInternalServerError)
+	}
+}
+
+func TestSSEBrokerRetention(t *testing.T) {
+	broker := NewSSEBroker(10)
+
+	// Nobody subscribed
+	broker.Publish("news", SSEEvent{Data: "a"})
+
+	if len(broker.topics) != 0 {
+		t.Fatalf("topics %v, want none", broker.topics)
+	}
+
+	_, _, cancel := broker.Subscribe("news", "")
+	broker.Publish("news", SSEEvent{Data: "a"})
+	cancel()
+
+	// Buffered for the reconnecting clients
+	broker.Publish("news", SSEEvent{Data: "b"})
+
+	replay, _, cancel := broker.Subscribe("news", "1")
+	cancel()
+
+	if len(replay) != 1 || replay[0].Data != "b" {
+		t.Errorf("replay %v, want the event b", replay)
+	}
+
+	// The topic expires with the retention
+	broker.topics["news"].idleSince = time.Now().Add(-defaultSSERetention)
+	broker.Publish("news", SSEEvent{Data: "c"})
+
+	if len(broker.topics) != 0 {
+		t.Errorf("topics %v, want the expired one removed", broker.topics)
+	}
+
+	// The idle topics are swept
+	for _, topic := range []string{"sports", "weather"} {
+		_, _, cancel := broker.Subscribe(topic, "")
+		broker.Publish(topic, SSEEvent{Data: "a"})
+		cancel()
+	}
+
+	broker.topics["sports"].idleSince = time.Now().Add(-defaultSSERetention)
+	broker.nextSweep = time.Time{}
+	broker.Publish("news", SSEEvent{Data: "d"})
+
+	if _, ok := broker.topics["sports"]; ok || len(broker.topics) != 1 {
+		t.Errorf("topics %v, want only weather", broker.topics)
+	}
+}
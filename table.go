@@ -0,0 +1,398 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pedia/router/radix"
+)
+
+// methodTable holds the handlers of the paths of a node of the tree, by
+// method index.
+// The paths registered with the same shape, i.e. the same segments and
+// params but different param names, share the same table.
+type methodTable struct {
+	entries []*tableEntry
+
+	// Path of the node of the tree, the first path registered with the
+	// shape
+	path string
+
+	// Segments of the shape of the paths
+	segments []string
+
+	// Other tables matching all the paths of the table, and some of them
+	covers   []*methodTable
+	overlaps []*methodTable
+
+	// Precomputed value of the Allow header, with the methods of the table
+	// and of the tables covering it
+	allow string
+
+	// Whether a table matching some of the paths only serves other methods,
+	// so the allowed methods depend on the path
+	partial bool
+}
+
+// tableEntry is the handler of a method in a method table
+type tableEntry struct {
+	endpoint *endpoint
+	handler  http.HandlerFunc
+
+	// Param names of the registered path, in path order
+	params []string
+}
+
+// entry returns the entry of the method, or the entry of the wild method
+func (table *methodTable) entry(methodIndex int) *tableEntry {
+	if methodIndex > -1 && methodIndex < len(table.entries) {
+		if e := table.entries[methodIndex]; e != nil {
+			return e
+		}
+	}
+
+	if wildIndex < len(table.entries) {
+		return table.entries[wildIndex]
+	}
+
+	return nil
+}
+
+// set sets the entry of the method
+func (table *methodTable) set(methodIndex int, e *tableEntry) {
+	for len(table.entries) <= methodIndex {
+		table.entries = append(table.entries, nil)
+	}
+
+	table.entries[methodIndex] = e
+}
+
+// shapeNode is a node of the tree of the table shapes, by segment, to find
+// the tables whose paths may overlap
+type shapeNode struct {
+	table  *methodTable
+	static map[string]*shapeNode
+	wild   map[string]*shapeNode // segments with params
+}
+
+// add adds the table to the tree
+func (n *shapeNode) add(table *methodTable) {
+	for _, segment := range table.segments {
+		children := &n.static
+		if strings.Contains(segment, "{") {
+			children = &n.wild
+		}
+
+		if *children == nil {
+			*children = make(map[string]*shapeNode)
+		}
+
+		child := (*children)[segment]
+		if child == nil {
+			child = new(shapeNode)
+			(*children)[segment] = child
+		}
+
+		n = child
+	}
+
+	n.table = table
+}
+
+// candidates calls fn with the tables whose paths may match the segments
+func (n *shapeNode) candidates(segments []string, fn func(*methodTable)) {
+	if len(segments) == 0 {
+		if n.table != nil {
+			fn(n.table)
+		}
+
+		return
+	}
+
+	segment := segments[0]
+
+	if strings.HasSuffix(segment, "{*}") {
+		// The catch-all matches the rest of the paths
+		n.each(fn)
+		return
+	}
+
+	visit := func(key string, child *shapeNode) {
+		if strings.HasSuffix(key, "{*}") {
+			child.each(fn)
+		} else {
+			child.candidates(segments[1:], fn)
+		}
+	}
+
+	if strings.Contains(segment, "{") {
+		for key, child := range n.static {
+			visit(key, child)
+		}
+	} else if child := n.static[segment]; child != nil {
+		visit(segment, child)
+	}
+
+	for key, child := range n.wild {
+		visit(key, child)
+	}
+}
+
+// each calls fn with the tables of the children of the node
+func (n *shapeNode) each(fn func(*methodTable)) {
+	for _, children := range []map[string]*shapeNode{n.static, n.wild} {
+		for _, child := range children {
+			if child.table != nil {
+				fn(child.table)
+			}
+
+			child.each(fn)
+		}
+	}
+}
+
+// link records the overlaps of the new table with the tables of the tree,
+// and adds it to the tree
+func (table *methodTable) link(shapes *shapeNode) {
+	shapes.candidates(table.segments, func(other *methodTable) {
+		overlap, covered := shapeOverlap(table.segments, other.segments)
+		if !overlap {
+			return
+		}
+
+		if covered {
+			table.covers = append(table.covers, other)
+		} else {
+			table.overlaps = append(table.overlaps, other)
+		}
+
+		if _, covers := shapeOverlap(other.segments, table.segments); covers {
+			other.covers = append(other.covers, table)
+		} else {
+			other.overlaps = append(other.overlaps, table)
+		}
+	})
+
+	shapes.add(table)
+}
+
+// updateAllow precomputes the Allow header of the table and of the tables
+// its methods are allowed for
+func (table *methodTable) updateAllow(router *Router) {
+	table.computeAllow(router)
+
+	for _, other := range table.covers {
+		other.computeAllow(router)
+	}
+
+	for _, other := range table.overlaps {
+		other.computeAllow(router)
+	}
+}
+
+func (table *methodTable) computeAllow(router *Router) {
+	var methods []string
+
+	add := func(t *methodTable) {
+		for _, e := range t.entries {
+			if e != nil && e.endpoint.method != http.MethodOptions && !containsString(methods, e.endpoint.method) {
+				methods = append(methods, e.endpoint.method)
+			}
+		}
+	}
+
+	add(table)
+
+	for _, other := range table.covers {
+		add(other)
+	}
+
+	table.partial = false
+
+	for _, other := range table.overlaps {
+		for _, e := range other.entries {
+			if e != nil && e.endpoint.method != http.MethodOptions && !containsString(methods, e.endpoint.method) {
+				table.partial = true
+			}
+		}
+	}
+
+	table.allow = router.joinAllowed(methods)
+}
+
+// precomputed reports whether the precomputed Allow header of the table
+// applies to the request: the allowed methods don't depend on the path,
+// the requested method isn't allowed and no route has predicates
+func (table *methodTable) precomputed(reqMethod string, r *http.Request) bool {
+	if table.partial {
+		return false
+	}
+
+	check := func(t *methodTable) bool {
+		for _, e := range t.entries {
+			if e == nil {
+				continue
+			}
+
+			if (e.endpoint.method == reqMethod && reqMethod != http.MethodOptions) || (r != nil && e.endpoint.hasPredicates()) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if !check(table) {
+		return false
+	}
+
+	for _, other := range table.covers {
+		if !check(other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pattern returns the registered path of the first entry of the table
+func (table *methodTable) pattern() string {
+	for _, e := range table.entries {
+		if e != nil {
+			return e.endpoint.path
+		}
+	}
+
+	return ""
+}
+
+// serve serves the request with the handler of the entry, after storing the
+// values of the params
+func (e *tableEntry) serve(w http.ResponseWriter, r *http.Request, params radix.Params) {
+	e.bind(e.handler, params)(w, r)
+}
+
+// bind returns the handler storing the values of the params before calling
+// the given handler
+func (e *tableEntry) bind(handler http.HandlerFunc, params radix.Params) http.HandlerFunc {
+	if len(params) == 0 {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		for i, p := range params {
+			r = setParam(r, e.params[i], p.Value)
+		}
+
+		handler(w, r)
+	}
+}
+
+// pathShape returns the path with the param names removed, so the paths
+// matching the same requests share the same node of the tree.
+// It also returns the param names of the path.
+func pathShape(path string) (string, []string) {
+	tokens, err := parsePattern(path)
+	if err != nil {
+		// Let the tree report the error
+		return path, nil
+	}
+
+	shape := new(strings.Builder)
+	names := make([]string, 0, len(tokens))
+
+	for _, tok := range tokens {
+		if tok.param == nil {
+			shape.WriteString(tok.literal)
+			continue
+		}
+
+		names = append(names, tok.param.name)
+
+		switch {
+		case tok.param.catchAll:
+			shape.WriteString("{*}")
+		case tok.param.regex != "":
+			shape.WriteString("{:" + tok.param.regex + "}")
+		default:
+			shape.WriteString("{}")
+		}
+	}
+
+	return shape.String(), names
+}
+
+// shapeSegments returns the segments of the shape of a path, the slashes
+// of the param regexes aside
+func shapeSegments(shape string) []string {
+	var segments []string
+
+	start, depth := 0, 0
+
+	for i := 0; i < len(shape); i++ {
+		switch shape[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '/':
+			if depth == 0 {
+				segments = append(segments, shape[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(segments, shape[start:])
+}
+
+// shapeOverlap reports whether a path can match both shapes, and whether
+// the other shape matches all the paths of the shape
+func shapeOverlap(shape, other []string) (overlap, covered bool) {
+	covered = true
+
+	for i := 0; ; i++ {
+		switch {
+		case i == len(shape) || i == len(other):
+			return len(shape) == len(other), covered && len(shape) == len(other)
+		case strings.HasSuffix(other[i], "{*}"):
+			// The catch-all matches the rest of the path
+			return true, covered && other[i] == "{*}"
+		case strings.HasSuffix(shape[i], "{*}"):
+			return true, false
+		}
+
+		segment, otherSegment := shape[i], other[i]
+
+		switch {
+		case segment == otherSegment:
+		case otherSegment == "{}" && segment != "":
+		case !strings.Contains(segment, "{") && !strings.Contains(otherSegment, "{"):
+			return false, false
+		default:
+			covered = false
+		}
+	}
+}
+
+// joinAllowed returns the value of the Allow header for the given methods
+func (router *Router) joinAllowed(allowed []string) string {
+	if len(allowed) == 0 {
+		return ""
+	}
+
+	// Add request method to list of allowed methods
+	allowed = append(allowed, http.MethodOptions)
+
+	// Sort allowed methods.
+	// sort.Strings(allowed) unfortunately causes unnecessary allocations
+	// due to allowed being moved to the heap and interface conversion
+	for i, l := 1, len(allowed); i < l; i++ {
+		for j := i; j > 0 && allowed[j] < allowed[j-1]; j-- {
+			allowed[j], allowed[j-1] = allowed[j-1], allowed[j]
+		}
+	}
+
+	// return as comma separated list
+	return strings.Join(allowed, ", ")
+}
@@ -0,0 +1,220 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestRouterMethodTable(t *testing.T) {
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name + ":" + UserValue(r, "id") + UserValue(r, "userID") + UserValue(r, "path")))
+		}
+	}
+
+	r := New()
+	r.GET("/users/{id}", handler("show"))
+	r.DELETE("/users/{userID}", handler("delete"))
+	r.PUT("/users/{id}", handler("update"))
+	r.POST("/files/new", handler("create"))
+	r.ANY("/files/{path:*}", handler("any"))
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		body   string
+		allow  string
+	}{
+		{http.MethodGet, "/users/7", http.StatusOK, "show:7", ""},
+		{http.MethodDelete, "/users/7", http.StatusOK, "delete:7", ""},
+		{http.MethodPut, "/users/7", http.StatusOK, "update:7", ""},
+		{http.MethodPost, "/users/7", http.StatusMethodNotAllowed, "", "DELETE, GET, OPTIONS, PUT"},
+		{http.MethodOptions, "/users/7", http.StatusOK, "", "DELETE, GET, OPTIONS, PUT"},
+		{http.MethodPost, "/files/new", http.StatusOK, "create:", ""},
+		{http.MethodGet, "/files/new", http.StatusOK, "any:new", ""},
+		{http.MethodGet, "/files/a/b", http.StatusOK, "any:a/b", ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("%s %s: response == %d %q, want %d %q", test.method, test.path, w.Code, w.Body.String(), test.code, test.body)
+		}
+
+		if allow := w.Header().Get("Allow"); allow != test.allow {
+			t.Errorf("%s %s: Allow == %q, want %q", test.method, test.path, allow, test.allow)
+		}
+	}
+
+	if recv := catchPanic(func() { r.GET("/users/{name}", handler("dup")) }); recv == nil {
+		t.Error("registering a path with the same shape did not panic")
+	}
+
+	if recv := catchPanic(func() { r.PATCH("/users/{id:[0-9]+}", handler("regex")) }); recv == nil {
+		t.Error("registering a param with another regex did not panic")
+	}
+}
+
+func TestRouterMethodTableAllowFallback(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.GET("/users/new", handler)
+	r.POST("/users/{id}", handler)
+	r.GET("/files/{id}", handler)
+	r.DELETE("/files/{path:*}", handler)
+	r.GET("/a/{id}", handler)
+	r.POST("/{x}/b", handler)
+	r.PATCH("/{x}/b", handler)
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		allow  string
+	}{
+		// POST /users/new is served by the less specific path
+		{http.MethodPost, "/users/new", http.StatusOK, ""},
+		{http.MethodOptions, "/users/new", http.StatusOK, "GET, OPTIONS, POST"},
+		{http.MethodPut, "/users/new", http.StatusMethodNotAllowed, "GET, OPTIONS, POST"},
+		{http.MethodPut, "/users/7", http.StatusMethodNotAllowed, "OPTIONS, POST"},
+
+		// The catch-all matches all the paths of /files/{id}
+		{http.MethodPut, "/files/7", http.StatusMethodNotAllowed, "DELETE, GET, OPTIONS"},
+		{http.MethodPut, "/files/7/raw", http.StatusMethodNotAllowed, "DELETE, OPTIONS"},
+
+		// /{x}/b matches some paths of /a/{id} only
+		{http.MethodPut, "/a/b", http.StatusMethodNotAllowed, "GET, OPTIONS, PATCH, POST"},
+		{http.MethodPut, "/a/c", http.StatusMethodNotAllowed, "GET, OPTIONS"},
+		{http.MethodOptions, "/a/b", http.StatusOK, "GET, OPTIONS, PATCH, POST"},
+		{http.MethodPost, "/a/c", http.StatusMethodNotAllowed, "GET, OPTIONS"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		if w.Code != test.code {
+			t.Errorf("%s %s: code == %d, want %d", test.method, test.path, w.Code, test.code)
+		}
+
+		if allow := w.Header().Get("Allow"); allow != test.allow {
+			t.Errorf("%s %s: Allow == %q, want %q", test.method, test.path, allow, test.allow)
+		}
+	}
+}
+
+func TestShapeOverlap(t *testing.T) {
+	tests := []struct {
+		shape, other     string
+		overlap, covered bool
+	}{
+		{"/users/new", "/users/{}", true, true},
+		{"/users/{}", "/users/new", true, false},
+		{"/users/{}", "/users/{:[0-9]+}", true, false},
+		{"/users/{:[0-9]+}", "/users/{}", true, true},
+		{"/users/{}", "/users/{}/posts", false, false},
+		{"/files/{}", "/files/{*}", true, true},
+		{"/files/{}/raw", "/files/{*}", true, true},
+		{"/files/{*}", "/files/{}", true, false},
+		{"/files", "/files/{*}", false, false},
+		{"/a/{}", "/{}/b", true, false},
+		{"/a/x", "/b/{}", false, false},
+		{"/re/{:[^/]+}/x", "/re/{}/x", true, true},
+	}
+
+	for _, test := range tests {
+		overlap, covered := shapeOverlap(shapeSegments(test.shape), shapeSegments(test.other))
+		if overlap != test.overlap || covered != test.covered {
+			t.Errorf("shapeOverlap(%s, %s) == %v, %v, want %v, %v", test.shape, test.other, overlap, covered, test.overlap, test.covered)
+		}
+	}
+}
+
+// largeRouter registers n resources, each with a collection and an item
+// path served by several methods
+func largeRouter(n int) *Router {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := New()
+
+	for i := 0; i < n; i++ {
+		collection := fmt.Sprintf("/api/v1/resource%d", i)
+		item := collection + "/{id}"
+
+		router.GET(collection, handler)
+		router.POST(collection, handler)
+		router.GET(item, handler)
+		router.PUT(item, handler)
+		router.PATCH(item, handler)
+		router.DELETE(item, handler)
+	}
+
+	return router
+}
+
+func BenchmarkLargeRouter(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		router := largeRouter(n)
+		path := fmt.Sprintf("/api/v1/resource%d/42", n/2)
+
+		b.Run(fmt.Sprintf("Routes=%d/Get", n*6), func(b *testing.B) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, path, nil)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				router.ServeHTTP(w, r)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Routes=%d/MethodNotAllowed", n*6), func(b *testing.B) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, path, nil)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				router.ServeHTTP(w, r)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Routes=%d/OPTIONS", n*6), func(b *testing.B) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodOptions, path, nil)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				router.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+func BenchmarkLargeRouterMemory(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("Routes=%d", n*6), func(b *testing.B) {
+			var before, after runtime.MemStats
+			var router *Router
+
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				router = largeRouter(n)
+			}
+
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+			runtime.KeepAlive(router)
+
+			b.ReportMetric(float64(after.HeapAlloc)-float64(before.HeapAlloc), "retained-B")
+		})
+	}
+}
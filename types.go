@@ -6,13 +6,29 @@ import (
 	"github.com/pedia/router/radix"
 )
 
+// Middleware wraps a handler with additional behaviour
+type Middleware func(http.HandlerFunc) http.HandlerFunc
+
 // Router is a http.Handler which can be used to dispatch requests to different
 // handler functions via configurable routes
 type Router struct {
-	trees              []*radix.Tree
+	tree               *radix.Tree
+	matcher            radix.Matcher
+	matcherTables      map[string]*methodTable
+	matcherPaths       map[string]bool
+	tables             map[string]*methodTable
+	shapes             shapeNode
 	treeMutable        bool
 	customMethodsIndex map[string]int
 	registeredPaths    map[string][]string
+	routes             []*Route
+	namedRoutes        map[string]*Route
+	endpoints          map[string]*endpoint
+	group              *Group
+	caseGroups         bool
+	errorGroups        bool
+	mediaVariants      bool
+	implicitHeads      map[string]*implicitHead
 
 	// If enabled, adds the matched route path onto the ctx.UserValue context
 	// before invoking the handler.
@@ -38,6 +54,32 @@ type Router struct {
 	// RedirectTrailingSlash is independent of this option.
 	RedirectFixedPath bool
 
+	// RedirectPolicy configures the redirects enabled by RedirectTrailingSlash
+	// and RedirectFixedPath. Groups and routes can override it.
+	// If it is not set, the requests are redirected to relative URLs with
+	// 301 for GET requests and 308 for the other methods.
+	RedirectPolicy *RedirectPolicy
+
+	// If enabled, the static segments of the routes are matched
+	// case-insensitively, and the request is served directly by the matched
+	// route instead of being redirected.
+	// The values of the params keep the case of the request, and the path
+	// of the matched route is returned by CanonicalPath.
+	// Groups can override it with Group.CaseInsensitive.
+	CaseInsensitive bool
+
+	// If enabled, the routes are matched against the escaped path of the
+	// request, so an encoded slash (%2F) doesn't separate segments, and the
+	// param values are unescaped individually after matching. The static
+	// parts of the patterns are escaped likewise.
+	// It must be set before registering the routes with params.
+	UseEscapedPath bool
+
+	// CatchAllDotDot defines how the '..' segments of the catch-all values
+	// are handled. They are kept by default.
+	// It must be set before registering the routes with catch-all params.
+	CatchAllDotDot DotDotPolicy
+
 	// If enabled, the router checks if another method is allowed for the
 	// current route, if the current request can not be routed.
 	// If this is the case, the request is answered with 'Method Not Allowed'
@@ -67,6 +109,57 @@ type Router struct {
 	// is called.
 	MethodNotAllowed http.HandlerFunc
 
+	// Configurable http.Handler which is called when the routes registered
+	// for the method and path can't satisfy the request, e.g. when no
+	// route is registered for the requested API version.
+	// If it is not set, http.StatusNotAcceptable is replied.
+	NotAcceptable http.HandlerFunc
+
+	// Configurable http.Handler which is called when the body of the request
+	// has a media type which the route doesn't consume.
+	// The "Accept" header with the consumed media types is set before the
+	// handler is called.
+	// If it is not set, http.StatusUnsupportedMediaType is replied.
+	UnsupportedMediaType http.HandlerFunc
+
+	// MaxSpecBodySize limits the size of the request bodies validated by
+	// the routes registered with HandleSpec. Larger bodies are answered with
+	// 413 Request Entity Too Large.
+	// If it is not set, 10MB is used.
+	MaxSpecBodySize int64
+
+	// MediaSuffixes maps the path suffixes, like ".csv", to the media types
+	// selecting the routes registered with HandleMedia regardless of the
+	// Accept header, e.g. /reports/42.csv for /reports/{id}.
+	// If it is not set, the suffixes of the common types are used.
+	MediaSuffixes map[string]string
+
+	// Versioning configures how the requested API version is extracted for
+	// routes registered with HandleVersion.
+	// If it is not set, the version is read from the API-Version header or
+	// from a vendor media type of the Accept header, like
+	// application/vnd.acme.v2+json.
+	Versioning *Versioning
+
+	// Problems renders the errors generated by the router, like 404 Not
+	// Found, 405 Method Not Allowed or the panics of the handlers when
+	// PanicHandler is not set, as RFC 9457 problem details.
+	// The custom handlers, like NotFound, take priority over it.
+	// If it is not set, only the status code is replied.
+	Problems *ProblemResponder
+
+	// Dialect translates the patterns of the routes registered after it
+	// is set, like HTTPRouterDialect for httprouter patterns.
+	// The patterns in the syntax of this router are kept as is.
+	Dialect Dialect
+
+	// ErrorHandler replies to the requests whose handler registered with
+	// HandleE returned an error, and to the panics of the handlers when
+	// PanicHandler is not set, as *PanicError.
+	// Groups can override it with Group.ErrorHandler.
+	// If it is not set, the status code of the HTTPError, or 500, is replied.
+	ErrorHandler ErrorHandler
+
 	// Function to handle panics recovered from http handlers.
 	// It should be used to generate a error page and return the http error code
 	// 500 (Internal Server Error).
@@ -81,5 +174,46 @@ type Router struct {
 // Group is a sub-router to group paths
 type Group struct {
 	router *Router
+	parent *Group
 	prefix string
+
+	caseInsensitive *bool
+	redirect        *RedirectPolicy
+	errorHandler    ErrorHandler
+	consumes        []string
+	produces        []string
+}
+
+// Route is a registered route.
+// Its methods allow to attach optional metadata after the registration.
+type Route struct {
+	router          *Router
+	method          string
+	path            string
+	name            string
+	handler         http.HandlerFunc
+	version         string
+	mediaType       string
+	predicates      []Predicate
+	inputs          []*InputRule
+	consumes        []string
+	produces        []string
+	negotiating     bool
+	redirect        *RedirectPolicy
+	caseInsensitive *bool
+	errorHandler    ErrorHandler
+
+	summary     string
+	description string
+	tags        []string
+	deprecated  bool
+	paramDocs   map[string]string
+	requestBody interface{}
+	responses   []routeResponse
+}
+
+type routeResponse struct {
+	code        int
+	description string
+	body        interface{}
 }
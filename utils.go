@@ -24,3 +24,17 @@ func UserValue(r *http.Request, key string) string {
 func UserValues(r *http.Request) map[string]string {
 	return radix.UserValues(r)
 }
+
+// setParam sets the value of the param, read by UserValue and, since Go
+// 1.22, by Request.PathValue.
+// The subtree param of the ServeMux patterns is internal, so it isn't set.
+func setParam(r *http.Request, key, value string) *http.Request {
+	if key == subtreeParam {
+		return r
+	}
+
+	r = radix.AddRequestValue(r, key, value)
+	setPathValue(r, key, value)
+
+	return r
+}
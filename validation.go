@@ -0,0 +1,62 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// FieldError describes a request input which is missing or invalid
+type FieldError struct {
+	// In is the location of the input: path, query, header, cookie or body
+	In string `json:"in"`
+	// Name is the name of the input, for body inputs the JSON pointer of
+	// the invalid value
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	if e.Name == "" {
+		return e.In + ": " + e.Message
+	}
+
+	return e.In + " " + e.Name + ": " + e.Message
+}
+
+// ValidationErrors is a list of request inputs which are missing or invalid
+type ValidationErrors []FieldError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// StatusCode returns 400 Bad Request
+func (errs ValidationErrors) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// writeValidationErrors replies with 400 Bad Request and the list of
+// violations as JSON, or as the "errors" member of a problem if
+// Router.Problems is set
+func (router *Router) writeValidationErrors(w http.ResponseWriter, r *http.Request, errs ValidationErrors) {
+	if router.Problems != nil {
+		router.writeProblem(w, r, http.StatusBadRequest, errs.Error(), map[string]interface{}{
+			"errors": errs,
+		})
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	json.NewEncoder(w).Encode(struct {
+		Errors ValidationErrors `json:"errors"`
+	}{errs})
+}
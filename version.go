@@ -0,0 +1,228 @@
+package router
+
+import (
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// VersionExtractor returns the API version requested by the request, or an
+// empty string if the request doesn't ask for a version
+type VersionExtractor func(r *http.Request) string
+
+// Versioning configures the dispatch of the routes registered for several
+// API versions with HandleVersion
+type Versioning struct {
+	// Extractor returns the requested version
+	Extractor VersionExtractor
+
+	// Vary lists the request headers read by the extractor, which are
+	// added to the Vary header of the responses of versioned routes
+	Vary []string
+
+	// Default is the version served to requests without version.
+	// If it is empty, the latest registered version is served.
+	Default string
+}
+
+var (
+	vendorVersionRe = regexp.MustCompile(`^application/vnd\.[^.+]+(?:\.[^.+]+)*?\.v([0-9][0-9A-Za-z.-]*)(?:\+[a-z]+)?$`)
+
+	defaultVersioning = &Versioning{
+		Extractor: FirstVersion(HeaderVersion("API-Version"), MediaTypeVersion()),
+		Vary:      []string{"Accept", "API-Version"},
+	}
+)
+
+// HeaderVersion returns an extractor reading the version from the given
+// request header
+func HeaderVersion(header string) VersionExtractor {
+	return func(r *http.Request) string {
+		return strings.TrimSpace(r.Header.Get(header))
+	}
+}
+
+// MediaTypeVersion returns an extractor reading the version from the
+// vendor media types of the Accept header, like application/vnd.acme.v2+json,
+// or from their version parameter, like application/json; version=2
+func MediaTypeVersion() VersionExtractor {
+	return func(r *http.Request) string {
+		for _, accept := range r.Header.Values("Accept") {
+			for _, mediaRange := range strings.Split(accept, ",") {
+				mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+				if err != nil {
+					continue
+				}
+
+				if v := params["version"]; v != "" {
+					return v
+				}
+
+				if m := vendorVersionRe.FindStringSubmatch(mediaType); m != nil {
+					return m[1]
+				}
+			}
+		}
+
+		return ""
+	}
+}
+
+// FirstVersion returns an extractor returning the first version found by
+// the given extractors
+func FirstVersion(extractors ...VersionExtractor) VersionExtractor {
+	return func(r *http.Request) string {
+		for _, extract := range extractors {
+			if v := extract(r); v != "" {
+				return v
+			}
+		}
+
+		return ""
+	}
+}
+
+// HandleVersion registers a new request handler for the given API version
+// of the path and method. Several versions may be registered for the same
+// path and method, the handler is selected by the version extracted from
+// the request according to Router.Versioning.
+// A route registered with Handle for the same path and method serves the
+// requests asking for an unknown version.
+// Requests asking for an unknown version are answered with 406 Not
+// Acceptable otherwise.
+func (router *Router) HandleVersion(method, path, version string, handler http.HandlerFunc) *Route {
+	if version == "" {
+		panic("version must not be empty")
+	}
+
+	return router.handle(&Route{method: method, path: path, handler: handler, version: normalizeVersion(version)})
+}
+
+// HandleVersion registers a new request handler for the given API version
+// of the path and method. See Router.HandleVersion.
+func (g *Group) HandleVersion(method, path, version string, handler http.HandlerFunc) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.HandleVersion(method, g.prefix+path, version, handler)
+}
+
+// GetVersion returns the API version of the route, if registered with
+// HandleVersion
+func (route *Route) GetVersion() string {
+	return route.version
+}
+
+// selectVersion returns the route of the requested version, or nil if no
+// route can serve it
+func (ep *endpoint) selectVersion(w http.ResponseWriter, r *http.Request, routes []*Route) *Route {
+	var fallback, latest *Route
+
+	for _, route := range routes {
+		switch {
+		case route.version == "":
+			if fallback == nil {
+				fallback = route
+			}
+		case latest == nil || compareVersions(route.version, latest.version) > 0:
+			latest = route
+		}
+	}
+
+	if latest == nil {
+		return fallback
+	}
+
+	versioning := ep.router.Versioning
+	if versioning == nil {
+		versioning = defaultVersioning
+	}
+
+	addVary(w.Header(), versioning.Vary...)
+
+	version := ""
+	if versioning.Extractor != nil {
+		version = normalizeVersion(versioning.Extractor(r))
+	}
+
+	if version == "" {
+		if versioning.Default == "" {
+			return latest
+		}
+
+		version = normalizeVersion(versioning.Default)
+	}
+
+	for _, route := range routes {
+		if route.version == version {
+			return route
+		}
+	}
+
+	return fallback
+}
+
+// normalizeVersion removes the 'v' prefix of the version
+func normalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && version[1] >= '0' && version[1] <= '9' {
+		version = version[1:]
+	}
+
+	return version
+}
+
+// compareVersions compares dot separated versions, numerically when
+// possible
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var pa, pb string
+		if i < len(as) {
+			pa = as[i]
+		}
+
+		if i < len(bs) {
+			pb = bs[i]
+		}
+
+		na, errA := strconv.Atoi(pa)
+		nb, errB := strconv.Atoi(pb)
+
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+
+			return 1
+		case (errA != nil || errB != nil) && pa != pb:
+			return strings.Compare(pa, pb)
+		}
+	}
+
+	return 0
+}
+
+// addVary adds the given headers to the Vary header, if not present yet
+func addVary(h http.Header, headers ...string) {
+	for _, header := range headers {
+		found := false
+
+		for _, vary := range h.Values("Vary") {
+			for _, v := range strings.Split(vary, ",") {
+				if strings.EqualFold(strings.TrimSpace(v), header) {
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			h.Add("Vary", header)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterHandleVersion(t *testing.T) {
+	versionHandler := func(version string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(version + ":" + UserValue(r, "id")))
+		}
+	}
+
+	r := New()
+	r.HandleVersion(http.MethodGet, "/users/{id}", "1", versionHandler("v1"))
+	r.HandleVersion(http.MethodGet, "/users/{id}", "v2", versionHandler("v2"))
+	r.HandleVersion(http.MethodGet, "/users/{id}", "1.5", versionHandler("v1.5"))
+	r.Group("/api").HandleVersion(http.MethodGet, "/items/", "1", versionHandler("items-v1"))
+	r.GET("/api/items/", versionHandler("items-fallback"))
+
+	tests := []struct {
+		path   string
+		header http.Header
+		code   int
+		body   string
+	}{
+		{"/users/7", nil, http.StatusOK, "v2:7"},
+		{"/users/7", http.Header{"Api-Version": {"1"}}, http.StatusOK, "v1:7"},
+		{"/users/7", http.Header{"Api-Version": {"v1.5"}}, http.StatusOK, "v1.5:7"},
+		{"/users/7", http.Header{"Accept": {"application/vnd.acme.v1+json"}}, http.StatusOK, "v1:7"},
+		{"/users/7", http.Header{"Accept": {"text/html, application/json; version=1.5"}}, http.StatusOK, "v1.5:7"},
+		{"/users/7", http.Header{"Api-Version": {"3"}}, http.StatusNotAcceptable, ""},
+		{"/api/items/", http.Header{"Api-Version": {"1"}}, http.StatusOK, "items-v1:"},
+		{"/api/items/", http.Header{"Api-Version": {"2"}}, http.StatusOK, "items-fallback:"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.code || w.Body.String() != test.body {
+			t.Errorf("%s %v: response == %d %q, want %d %q", test.path, test.header, w.Code, w.Body.String(), test.code, test.body)
+		}
+
+		if vary := w.Header().Values("Vary"); len(vary) != 2 {
+			t.Errorf("%s %v: Vary == %v", test.path, test.header, vary)
+		}
+	}
+
+	// Default version and custom extractor
+	r.Versioning = &Versioning{
+		Extractor: HeaderVersion("X-Version"),
+		Vary:      []string{"X-Version"},
+		Default:   "1",
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/8", nil))
+
+	if w.Body.String() != "v1:8" || w.Header().Get("Vary") != "X-Version" {
+		t.Errorf("unexpected default version response %q, Vary: %v", w.Body.String(), w.Header()["Vary"])
+	}
+
+	r.NotAcceptable = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/8", nil)
+	req.Header.Set("X-Version", "9")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("custom NotAcceptable handler not called: %d", w.Code)
+	}
+
+	if recv := catchPanic(func() { r.HandleVersion(http.MethodGet, "/users/{id}", "v1", versionHandler("dup")) }); recv == nil {
+		t.Error("registering a duplicated version did not panic")
+	}
+
+	r.GET("/users/{id}", versionHandler("plain"))
+	if recv := catchPanic(func() { r.GET("/users/{id}", versionHandler("plain")) }); recv == nil {
+		t.Error("registering a duplicated route did not panic")
+	}
+
+	if got := len(r.List()[http.MethodGet]); got != 2 {
+		t.Errorf("len(List()[GET]) == %d, want 2", got)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1", "2", -1},
+		{"10", "9", 1},
+		{"1.10", "1.9", 1},
+		{"1.0", "1", 1},
+		{"2", "2", 0},
+		{"beta", "alpha", 1},
+	}
+
+	for _, test := range tests {
+		if got := compareVersions(test.a, test.b); got != test.want {
+			t.Errorf("compareVersions(%q, %q) == %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
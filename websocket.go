@@ -0,0 +1,678 @@
+package router
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // required by RFC 6455
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WSMessageType is the type of a WebSocket message
+type WSMessageType int
+
+// The types of the WebSocket messages
+const (
+	WSTextMessage   WSMessageType = 1
+	WSBinaryMessage WSMessageType = 2
+)
+
+// The status codes of the WebSocket close frames, see RFC 6455 section 7.4
+const (
+	WSCloseNormal             = 1000
+	WSCloseGoingAway          = 1001
+	WSCloseProtocolError      = 1002
+	WSCloseUnsupportedData    = 1003
+	WSCloseNoStatus           = 1005
+	WSCloseAbnormal           = 1006
+	WSCloseInvalidPayload     = 1007
+	WSClosePolicyViolation    = 1008
+	WSCloseMessageTooBig      = 1009
+	WSCloseMandatoryExtension = 1010
+	WSCloseInternalError      = 1011
+)
+
+const (
+	wsAcceptGUID            = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxControlPayload     = 125
+	defaultWSMaxMessageSize = 1 << 20
+)
+
+// The opcodes of the WebSocket frames
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xa
+)
+
+// ErrWSClosed is returned when writing to a WebSocket connection after the
+// close frame was sent
+var ErrWSClosed = errors.New("websocket: connection closed")
+
+// WSCloseError is returned by WSConn.ReadMessage when the connection is
+// closed, by the peer or because the peer broke the protocol
+type WSCloseError struct {
+	Code   int
+	Reason string
+}
+
+func (err *WSCloseError) Error() string {
+	if err.Reason == "" {
+		return fmt.Sprintf("websocket: close %d", err.Code)
+	}
+
+	return fmt.Sprintf("websocket: close %d: %s", err.Code, err.Reason)
+}
+
+// WSHandler serves a WebSocket connection.
+// The connection is closed when the handler returns.
+type WSHandler func(conn *WSConn, r *http.Request)
+
+// WSConfig configures the WebSocket routes registered with WS
+type WSConfig struct {
+	// CheckOrigin reports whether the Origin of the handshake request is
+	// allowed. By default, requests whose Origin header isn't the host of
+	// the request are rejected with 403 Forbidden.
+	CheckOrigin func(r *http.Request) bool
+
+	// Subprotocols are the subprotocols supported by the route, by order of
+	// preference
+	Subprotocols []string
+
+	// MaxMessageSize is the maximum size of the messages read, 1MB by
+	// default. Larger messages close the connection with status 1009.
+	MaxMessageSize int64
+}
+
+// WSOption configures a WebSocket route
+type WSOption func(*WSConfig)
+
+// WSOrigins allows the handshake requests from the given origins, like
+// "https://example.com", or from any origin with "*"
+func WSOrigins(origins ...string) WSOption {
+	return func(c *WSConfig) {
+		c.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+
+			for _, o := range origins {
+				if o == "*" || strings.EqualFold(o, origin) {
+					return true
+				}
+			}
+
+			return origin == ""
+		}
+	}
+}
+
+// WSCheckOrigin sets the function checking the origin of the handshake
+// requests
+func WSCheckOrigin(check func(r *http.Request) bool) WSOption {
+	return func(c *WSConfig) {
+		c.CheckOrigin = check
+	}
+}
+
+// WSSubprotocols sets the subprotocols supported by the route, by order of
+// preference
+func WSSubprotocols(subprotocols ...string) WSOption {
+	return func(c *WSConfig) {
+		c.Subprotocols = subprotocols
+	}
+}
+
+// WSMaxMessageSize sets the maximum size of the messages read.
+// It panics if the size isn't positive.
+func WSMaxMessageSize(size int64) WSOption {
+	if size <= 0 {
+		panic("max message size must be positive")
+	}
+
+	return func(c *WSConfig) {
+		c.MaxMessageSize = size
+	}
+}
+
+// WS registers a GET route upgrading the requests to WebSocket connections,
+// according to RFC 6455, and serving them with the handler.
+// Handshake requests are rejected with 426 Upgrade Required for another
+// protocol version, 400 Bad Request if invalid and 403 Forbidden if their
+// origin isn't allowed.
+func (router *Router) WS(path string, handler WSHandler, opts ...WSOption) *Route {
+	if handler == nil {
+		panic("handler must not be nil")
+	}
+
+	config := &WSConfig{MaxMessageSize: defaultWSMaxMessageSize}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return router.HandleRoute(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request) {
+		conn := router.upgradeWS(w, r, config)
+		if conn == nil {
+			return
+		}
+
+		defer conn.closeAfter()
+
+		handler(conn, r)
+	})
+}
+
+// WS registers a GET route upgrading the requests to WebSocket
+// connections. See Router.WS.
+func (g *Group) WS(path string, handler WSHandler, opts ...WSOption) *Route {
+	validatePath(path)
+
+	defer g.registering()()
+
+	return g.router.WS(g.prefix+path, handler, opts...)
+}
+
+// upgradeWS performs the handshake of the request, and returns the
+// connection or nil if the request was rejected
+func (router *Router) upgradeWS(w http.ResponseWriter, r *http.Request, config *WSConfig) *WSConn {
+	switch {
+	case !headerHasToken(r.Header, "Connection", "upgrade"), !headerHasToken(r.Header, "Upgrade", "websocket"):
+		w.Header().Set("Upgrade", "websocket")
+		router.writeProblem(w, r, http.StatusUpgradeRequired, "websocket upgrade required", nil)
+
+		return nil
+	case r.Header.Get("Sec-WebSocket-Version") != "13":
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		router.writeProblem(w, r, http.StatusUpgradeRequired, "unsupported websocket version", nil)
+
+		return nil
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		router.writeProblem(w, r, http.StatusBadRequest, "invalid Sec-WebSocket-Key", nil)
+		return nil
+	}
+
+	checkOrigin := config.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+
+	if !checkOrigin(r) {
+		router.writeProblem(w, r, http.StatusForbidden, "origin not allowed", nil)
+		return nil
+	}
+
+	subprotocol := selectSubprotocol(r, config.Subprotocols)
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		router.writeProblem(w, r, http.StatusInternalServerError, "", nil)
+		return nil
+	}
+
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil
+	}
+
+	// The server deadlines don't apply to the hijacked connection
+	netConn.SetDeadline(time.Time{}) //nolint:errcheck
+
+	var b strings.Builder
+
+	b.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	b.WriteString("Sec-WebSocket-Accept: " + wsAccept(key) + "\r\n")
+
+	if subprotocol != "" {
+		b.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+	}
+
+	b.WriteString("\r\n")
+
+	if _, err := rw.WriteString(b.String()); err != nil || rw.Flush() != nil {
+		netConn.Close()
+		return nil
+	}
+
+	conn := newWSConn(netConn, rw.Reader, false)
+	conn.subprotocol = subprotocol
+	if config.MaxMessageSize > 0 {
+		conn.maxMessageSize = config.MaxMessageSize
+	}
+
+	return conn
+}
+
+// wsAccept returns the Sec-WebSocket-Accept value of the key
+func wsAccept(key string) string {
+	h := sha1.New() //nolint:gosec // required by RFC 6455
+	h.Write([]byte(key + wsAcceptGUID))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// sameOrigin reports whether the request has no Origin header, or whether
+// its host is the host of the request
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// selectSubprotocol returns the first supported subprotocol offered by the
+// request
+func selectSubprotocol(r *http.Request, supported []string) string {
+	offered := headerTokens(r.Header, "Sec-WebSocket-Protocol")
+
+	for _, s := range supported {
+		for _, o := range offered {
+			if s == o {
+				return s
+			}
+		}
+	}
+
+	return ""
+}
+
+// headerTokens returns the comma separated tokens of the header
+func headerTokens(h http.Header, name string) []string {
+	var tokens []string
+
+	for _, v := range h.Values(name) {
+		for _, token := range strings.Split(v, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// headerHasToken reports whether the header has the token,
+// case-insensitively
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, t := range headerTokens(h, name) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WSConn is a WebSocket connection.
+// A goroutine may read while another one writes, the writes are
+// serialized.
+type WSConn struct {
+	conn   net.Conn
+	br     *bufio.Reader
+	client bool
+
+	subprotocol    string
+	maxMessageSize int64
+	pingHandler    func(data []byte) error
+	pongHandler    func(data []byte) error
+
+	writeMu   sync.Mutex
+	closeSent bool
+}
+
+func newWSConn(conn net.Conn, br *bufio.Reader, client bool) *WSConn {
+	return &WSConn{conn: conn, br: br, client: client, maxMessageSize: defaultWSMaxMessageSize}
+}
+
+// Subprotocol returns the negotiated subprotocol, if any
+func (c *WSConn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// NetConn returns the underlying connection
+func (c *WSConn) NetConn() net.Conn {
+	return c.conn
+}
+
+// SetReadDeadline sets the deadline of the reads
+func (c *WSConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline of the writes
+func (c *WSConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// SetPingHandler sets the function called with the pings received by
+// ReadMessage. By default, the pings are answered with a pong.
+func (c *WSConn) SetPingHandler(h func(data []byte) error) {
+	c.pingHandler = h
+}
+
+// SetPongHandler sets the function called with the pongs received by
+// ReadMessage
+func (c *WSConn) SetPongHandler(h func(data []byte) error) {
+	c.pongHandler = h
+}
+
+// ReadMessage reads the next data message, answering the pings and
+// handling the pongs received before it.
+// When the peer closes the connection, the close frame is answered and a
+// *WSCloseError is returned. When the peer breaks the protocol, or sends
+// a message larger than the limit, the connection is closed with the
+// matching status code and a *WSCloseError is returned too.
+func (c *WSConn) ReadMessage() (WSMessageType, []byte, error) {
+	var (
+		messageType WSMessageType
+		message     []byte
+	)
+
+	for {
+		fin, opcode, payload, err := c.readFrame(len(message))
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case wsPing:
+			if err := c.handlePing(payload); err != nil {
+				return 0, nil, err
+			}
+
+			continue
+		case wsPong:
+			if c.pongHandler != nil {
+				if err := c.pongHandler(payload); err != nil {
+					return 0, nil, err
+				}
+			}
+
+			continue
+		case wsClose:
+			return 0, nil, c.handleClose(payload)
+		case wsContinuation:
+			if messageType == 0 {
+				return 0, nil, c.fail(WSCloseProtocolError, "unexpected continuation frame")
+			}
+		case wsText, wsBinary:
+			if messageType != 0 {
+				return 0, nil, c.fail(WSCloseProtocolError, "expected continuation frame")
+			}
+
+			messageType = WSMessageType(opcode)
+		default:
+			return 0, nil, c.fail(WSCloseProtocolError, fmt.Sprintf("unknown opcode %d", opcode))
+		}
+
+		message = append(message, payload...)
+
+		if fin {
+			if messageType == WSTextMessage && !utf8.Valid(message) {
+				return 0, nil, c.fail(WSCloseInvalidPayload, "invalid UTF-8 in text message")
+			}
+
+			return messageType, message, nil
+		}
+	}
+}
+
+// readFrame reads a frame, whose payload is added to a message of the
+// given length if it's a data frame
+func (c *WSConn) readFrame(messageLen int) (bool, byte, []byte, error) {
+	var header [8]byte
+
+	if _, err := io.ReadFull(c.br, header[:2]); err != nil {
+		return false, 0, nil, c.abort(err)
+	}
+
+	fin, rsv, opcode := header[0]&0x80 != 0, header[0]&0x70, header[0]&0x0f
+	masked, length := header[1]&0x80 != 0, int64(header[1]&0x7f)
+
+	switch length {
+	case 126:
+		if _, err := io.ReadFull(c.br, header[:2]); err != nil {
+			return false, 0, nil, c.abort(err)
+		}
+
+		length = int64(binary.BigEndian.Uint16(header[:2]))
+	case 127:
+		if _, err := io.ReadFull(c.br, header[:8]); err != nil {
+			return false, 0, nil, c.abort(err)
+		}
+
+		length = int64(binary.BigEndian.Uint64(header[:8]))
+		if length < 0 {
+			return false, 0, nil, c.fail(WSCloseProtocolError, "invalid payload length")
+		}
+	}
+
+	switch {
+	case rsv != 0:
+		return false, 0, nil, c.fail(WSCloseProtocolError, "reserved bits set")
+	case masked == c.client:
+		return false, 0, nil, c.fail(WSCloseProtocolError, "invalid frame masking")
+	case opcode >= wsClose && (!fin || length > wsMaxControlPayload):
+		return false, 0, nil, c.fail(WSCloseProtocolError, "invalid control frame")
+	case opcode < wsClose && int64(messageLen)+length > c.maxMessageSize:
+		return false, 0, nil, c.fail(WSCloseMessageTooBig, "message too big")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, c.abort(err)
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, c.abort(err)
+	}
+
+	if masked {
+		maskBytes(mask, payload)
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// handlePing calls the ping handler, or answers the ping
+func (c *WSConn) handlePing(data []byte) error {
+	if c.pingHandler != nil {
+		return c.pingHandler(data)
+	}
+
+	if err := c.writeFrame(wsPong, data); err != nil && !errors.Is(err, ErrWSClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// handleClose answers the close frame and closes the connection
+func (c *WSConn) handleClose(payload []byte) error {
+	closeErr := &WSCloseError{Code: WSCloseNoStatus}
+
+	switch {
+	case len(payload) == 1:
+		return c.fail(WSCloseProtocolError, "invalid close frame")
+	case len(payload) >= 2:
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Reason = string(payload[2:])
+
+		if !validCloseCode(closeErr.Code) {
+			return c.fail(WSCloseProtocolError, "invalid close code")
+		} else if !utf8.ValidString(closeErr.Reason) {
+			return c.fail(WSCloseInvalidPayload, "invalid UTF-8 in close reason")
+		}
+	}
+
+	reply := []byte(nil)
+	if closeErr.Code != WSCloseNoStatus {
+		reply = payload[:2]
+	}
+
+	c.writeFrame(wsClose, reply) //nolint:errcheck
+	c.conn.Close()
+
+	return closeErr
+}
+
+// validCloseCode reports whether the code may be sent in a close frame
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1011:
+		return true
+	default:
+		return code >= 3000 && code <= 4999
+	}
+}
+
+// fail closes the connection with the code, when the peer broke the
+// protocol
+func (c *WSConn) fail(code int, reason string) error {
+	c.Close(code, reason) //nolint:errcheck
+
+	return &WSCloseError{Code: code, Reason: reason}
+}
+
+// abort closes the connection on a read error
+func (c *WSConn) abort(err error) error {
+	c.conn.Close()
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &WSCloseError{Code: WSCloseAbnormal}
+	}
+
+	return err
+}
+
+// WriteMessage writes a message in a single frame
+func (c *WSConn) WriteMessage(messageType WSMessageType, data []byte) error {
+	if messageType != WSTextMessage && messageType != WSBinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+
+	return c.writeFrame(byte(messageType), data)
+}
+
+// Ping sends a ping, whose pong is handled by the pong handler during
+// ReadMessage
+func (c *WSConn) Ping(data []byte) error {
+	if len(data) > wsMaxControlPayload {
+		return errors.New("websocket: control frame payload too long")
+	}
+
+	return c.writeFrame(wsPing, data)
+}
+
+// Close sends a close frame with the status code and reason, and closes
+// the connection
+func (c *WSConn) Close(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+
+	// Truncate the reason without breaking its UTF-8
+	for len(payload) > wsMaxControlPayload || !utf8.Valid(payload[2:]) {
+		payload = payload[:len(payload)-1]
+	}
+
+	err := c.writeFrame(wsClose, payload)
+	if errors.Is(err, ErrWSClosed) {
+		err = nil
+	}
+
+	if closeErr := c.conn.Close(); err == nil && !errors.Is(closeErr, net.ErrClosed) {
+		err = closeErr
+	}
+
+	return err
+}
+
+// closeAfter closes the connection once the handler returned, if not
+// closed yet
+func (c *WSConn) closeAfter() {
+	c.Close(WSCloseNormal, "") //nolint:errcheck
+}
+
+// writeFrame writes a frame, masked if written by a client
+func (c *WSConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrWSClosed
+	}
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|opcode)
+
+	maskBit := byte(0)
+	if c.client {
+		maskBit = 0x80
+	}
+
+	switch n := len(payload); {
+	case n <= wsMaxControlPayload:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126, byte(n>>8), byte(n))
+	default:
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(n))
+
+		frame = append(frame, maskBit|127)
+		frame = append(frame, length[:]...)
+	}
+
+	if c.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		maskBytes(mask, frame[start:])
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	if opcode == wsClose {
+		c.closeSent = true
+	}
+
+	_, err := c.conn.Write(frame)
+
+	return err
+}
+
+// maskBytes masks or unmasks the data with the key
+func maskBytes(key [4]byte, data []byte) {
+	for i := range data {
+		data[i] ^= key[i&3]
+	}
+}
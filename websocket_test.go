@@ -0,0 +1,369 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dialWS performs the handshake with the server and returns the client
+// connection, or the response if the handshake failed
+func dialWS(t *testing.T, server *httptest.Server, path string, header http.Header) (*WSConn, *http.Response) {
+	t.Helper()
+
+	netConn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")))
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	if err := req.Write(netConn); err != nil {
+		t.Fatal(err)
+	}
+
+	br := bufio.NewReader(netConn)
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		netConn.Close()
+		return nil, resp
+	}
+
+	if got, want := resp.Header.Get("Sec-WebSocket-Accept"), wsAccept(req.Header.Get("Sec-WebSocket-Key")); got != want {
+		t.Errorf("Sec-WebSocket-Accept %q, want %q", got, want)
+	}
+
+	conn := newWSConn(netConn, br, true)
+	conn.subprotocol = resp.Header.Get("Sec-WebSocket-Protocol")
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second)) //nolint:errcheck
+
+	return conn, resp
+}
+
+// writeRawFrame writes a frame without masking it
+func writeRawFrame(t *testing.T, conn *WSConn, fin bool, opcode byte, payload []byte) {
+	t.Helper()
+
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+
+	if _, err := conn.conn.Write(append([]byte{b0, byte(len(payload))}, payload...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeMaskedFrame writes a frame masked with a zero key
+func writeMaskedFrame(t *testing.T, conn *WSConn, fin bool, opcode byte, payload []byte) {
+	t.Helper()
+
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+
+	frame := append([]byte{b0, 0x80 | byte(len(payload)), 0, 0, 0, 0}, payload...)
+	if _, err := conn.conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectClose reads until the connection is closed and checks the close
+// code
+func expectClose(t *testing.T, conn *WSConn, code int) {
+	t.Helper()
+
+	_, _, err := conn.ReadMessage()
+
+	var closeErr *WSCloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != code {
+		t.Errorf("read error %v, want close %d", err, code)
+	}
+}
+
+func TestRouterWS(t *testing.T) {
+	serverErrs := make(chan error, 10)
+	pongs := make(chan string, 1)
+
+	r := New()
+	r.WS("/echo/{room}", func(conn *WSConn, r *http.Request) {
+		conn.SetPongHandler(func(data []byte) error {
+			pongs <- string(data)
+			return nil
+		})
+
+		if err := conn.WriteMessage(WSTextMessage, []byte("room "+UserValue(r, "room")+" "+conn.Subprotocol())); err != nil {
+			serverErrs <- err
+			return
+		}
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				serverErrs <- err
+				return
+			}
+
+			if string(data) == "ping me" {
+				conn.Ping([]byte("server ping")) //nolint:errcheck
+			}
+
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				serverErrs <- err
+				return
+			}
+		}
+	}, WSSubprotocols("chat", "v1"), WSMaxMessageSize(16))
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	conn, resp := dialWS(t, server, "/echo/lobby", http.Header{"Sec-Websocket-Protocol": {"v1, chat"}})
+	if conn == nil {
+		t.Fatalf("handshake failed with status %d", resp.StatusCode)
+	}
+
+	pingReplies := make(chan string, 1)
+	conn.SetPongHandler(func(data []byte) error {
+		pingReplies <- string(data)
+		return nil
+	})
+
+	read := func(wantType WSMessageType, want string) {
+		t.Helper()
+
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if messageType != wantType || string(data) != want {
+			t.Errorf("read %d %q, want %d %q", messageType, data, wantType, want)
+		}
+	}
+
+	read(WSTextMessage, "room lobby chat")
+
+	conn.WriteMessage(WSTextMessage, []byte("hello")) //nolint:errcheck
+	read(WSTextMessage, "hello")
+
+	conn.WriteMessage(WSBinaryMessage, []byte{0, 1, 2}) //nolint:errcheck
+	read(WSBinaryMessage, "\x00\x01\x02")
+
+	// A fragmented message with a ping in between
+	writeMaskedFrame(t, conn, false, wsText, []byte("frag"))
+	conn.Ping([]byte("client ping")) //nolint:errcheck
+	writeMaskedFrame(t, conn, true, wsContinuation, []byte("mented"))
+	read(WSTextMessage, "fragmented")
+
+	if got := <-pingReplies; got != "client ping" {
+		t.Errorf("pong %q, want %q", got, "client ping")
+	}
+
+	// The server pings, the client answers while reading
+	conn.WriteMessage(WSTextMessage, []byte("ping me")) //nolint:errcheck
+	read(WSTextMessage, "ping me")
+
+	select {
+	case got := <-pongs:
+		if got != "server ping" {
+			t.Errorf("pong %q, want %q", got, "server ping")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("the server didn't receive the pong")
+	}
+
+	// The close code is echoed
+	if err := conn.Close(4000, "bye"); err != nil {
+		t.Error(err)
+	}
+
+	var closeErr *WSCloseError
+	if err := <-serverErrs; !errors.As(err, &closeErr) || closeErr.Code != 4000 || closeErr.Reason != "bye" {
+		t.Errorf("server read error %v, want close 4000 bye", err)
+	}
+
+	tests := []struct {
+		name string
+		send func(conn *WSConn)
+		code int
+	}{
+		{"too big", func(conn *WSConn) {
+			conn.WriteMessage(WSTextMessage, bytes.Repeat([]byte("a"), 17)) //nolint:errcheck
+		}, WSCloseMessageTooBig},
+		{"too big fragments", func(conn *WSConn) {
+			writeMaskedFrame(t, conn, false, wsBinary, bytes.Repeat([]byte("a"), 10))
+			writeMaskedFrame(t, conn, true, wsContinuation, bytes.Repeat([]byte("a"), 10))
+		}, WSCloseMessageTooBig},
+		{"unmasked", func(conn *WSConn) {
+			writeRawFrame(t, conn, true, wsText, []byte("hi"))
+		}, WSCloseProtocolError},
+		{"invalid utf-8", func(conn *WSConn) {
+			conn.WriteMessage(WSTextMessage, []byte{0xff, 0xfe}) //nolint:errcheck
+		}, WSCloseInvalidPayload},
+		{"unknown opcode", func(conn *WSConn) {
+			writeMaskedFrame(t, conn, true, 0x3, nil)
+		}, WSCloseProtocolError},
+		{"unexpected continuation", func(conn *WSConn) {
+			writeMaskedFrame(t, conn, true, wsContinuation, []byte("x"))
+		}, WSCloseProtocolError},
+		{"fragmented control frame", func(conn *WSConn) {
+			writeMaskedFrame(t, conn, false, wsPing, nil)
+		}, WSCloseProtocolError},
+		{"invalid close code", func(conn *WSConn) {
+			writeMaskedFrame(t, conn, true, wsClose, []byte{0x03, 0xed}) // 1005
+		}, WSCloseProtocolError},
+	}
+
+	for _, test := range tests {
+		conn, _ := dialWS(t, server, "/echo/lobby", nil)
+		read := func() {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		read()
+		test.send(conn)
+		expectClose(t, conn, test.code)
+
+		if err := <-serverErrs; !errors.As(err, &closeErr) || closeErr.Code != test.code {
+			t.Errorf("%s: server read error %v, want close %d", test.name, err, test.code)
+		}
+	}
+}
+
+func TestRouterWSHandshake(t *testing.T) {
+	r := New()
+	r.WS("/ws", func(conn *WSConn, r *http.Request) {
+		conn.WriteMessage(WSTextMessage, []byte(conn.Subprotocol())) //nolint:errcheck
+	})
+	r.WS("/public", func(conn *WSConn, r *http.Request) {}, WSOrigins("https://example.com"))
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	tests := []struct {
+		path   string
+		header http.Header
+		code   int
+	}{
+		{"/ws", nil, http.StatusSwitchingProtocols},
+		{"/ws", http.Header{"Origin": {server.URL}}, http.StatusSwitchingProtocols},
+		{"/ws", http.Header{"Origin": {"https://evil.example"}}, http.StatusForbidden},
+		{"/public", http.Header{"Origin": {"https://example.com"}}, http.StatusSwitchingProtocols},
+		{"/public", http.Header{"Origin": {"https://evil.example"}}, http.StatusForbidden},
+		{"/ws", http.Header{"Sec-Websocket-Version": {"8"}}, http.StatusUpgradeRequired},
+		{"/ws", http.Header{"Upgrade": {"h2c"}}, http.StatusUpgradeRequired},
+		{"/ws", http.Header{"Sec-Websocket-Key": {"short"}}, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		conn, resp := dialWS(t, server, test.path, test.header)
+		if resp.StatusCode != test.code {
+			t.Errorf("%s %v: status %d, want %d", test.path, test.header, resp.StatusCode, test.code)
+		}
+
+		if conn == nil {
+			continue
+		}
+
+		if test.path == "/ws" {
+			if _, data, err := conn.ReadMessage(); err != nil || len(data) != 0 {
+				t.Errorf("read %q %v, want no subprotocol", data, err)
+			}
+		}
+
+		expectClose(t, conn, WSCloseNormal)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+	if w.Code != http.StatusUpgradeRequired || !strings.EqualFold(w.Header().Get("Upgrade"), "websocket") {
+		t.Errorf("plain request: status %d, Upgrade %q", w.Code, w.Header().Get("Upgrade"))
+	}
+}
+
+// expiredHijacker hijacks a connection whose deadline has already passed,
+// as servers may leave the deadline of the request on the connection
+type expiredHijacker struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (h expiredHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.conn.SetDeadline(time.Now().Add(-time.Second)) //nolint:errcheck
+	return h.conn, bufio.NewReadWriter(bufio.NewReader(h.conn), bufio.NewWriter(h.conn)), nil
+}
+
+func TestRouterWSDeadline(t *testing.T) {
+	serverErrs := make(chan error, 1)
+
+	r := New()
+	r.WS("/ws", func(conn *WSConn, r *http.Request) {
+		messageType, data, err := conn.ReadMessage()
+		if err == nil {
+			err = conn.WriteMessage(messageType, data)
+		}
+		serverErrs <- err
+	})
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")))
+
+	go r.ServeHTTP(expiredHijacker{httptest.NewRecorder(), serverConn}, req)
+
+	br := bufio.NewReader(clientConn)
+	if resp, err := http.ReadResponse(br, req); err != nil || resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake failed: %v", err)
+	}
+
+	conn := newWSConn(clientConn, br, true)
+	clientConn.SetDeadline(time.Now().Add(5 * time.Second)) //nolint:errcheck
+
+	if err := conn.WriteMessage(WSTextMessage, []byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, data, err := conn.ReadMessage(); err != nil || string(data) != "hi" {
+		t.Errorf("read %q %v, want %q", data, err, "hi")
+	}
+
+	if err := <-serverErrs; err != nil {
+		t.Errorf("server error %v", err)
+	}
+}
+
+func TestWSMaxMessageSize(t *testing.T) {
+	if recv := catchPanic(func() { WSMaxMessageSize(0) }); recv == nil {
+		t.Error("a zero max message size did not panic")
+	}
+}